@@ -13,8 +13,11 @@ import (
     "github.com/ethereum/go-ethereum/ethclient"
     "github.com/ethereum/go-ethereum/rpc"
 
+    "eth_validator_api/internal/adapter/builder"
     "eth_validator_api/internal/adapter/consensus"
     "eth_validator_api/internal/adapter/execution"
+    "eth_validator_api/internal/adapter/relay"
+    "eth_validator_api/internal/handler"
     "eth_validator_api/internal/usecase"
     httpPkg "eth_validator_api/pkg/http"
     "eth_validator_api/pkg/config"
@@ -40,7 +43,8 @@ func main() {
     }
 
     consClient, err := consensus.NewConsensusClient(
-        cfg.Ethereum.RPCHTTP,
+        cfg.Beacon.HTTP,
+        cfg.Beacon.Headers,
         cfg.Retry.SyncDuties.MaxRetries,
         cfg.Retry.SyncDuties.Backoff,
         cfg.Retry.SyncDuties.Timeout,
@@ -49,6 +53,21 @@ func main() {
         zap.L().Fatal("init consensus client", zap.Error(err))
     }
 
+    headTracker := consensus.NewHeadTracker(consClient, cfg.HeadTracker.PollInterval)
+    if err := headTracker.Refresh(context.Background()); err != nil {
+        zap.L().Warn("initial head refresh failed", zap.Error(err))
+    }
+    trackerCtx, stopTracker := context.WithCancel(context.Background())
+    defer stopTracker()
+    go headTracker.Run(trackerCtx)
+
+    validators, err := consensus.NewValidatorRegistry(consClient, cfg.Validators.RegistryFile, cfg.Validators.PollInterval)
+    if err != nil {
+        zap.L().Fatal("load validator registry", zap.Error(err))
+    }
+    consClient.SetValidatorRegistry(validators)
+    go validators.Run(trackerCtx)
+
     cache_duties, err := consensus.NewSyncDutiesCache(
         cfg.Cache.SyncDuties.MaxEntries,
         cfg.Cache.SyncDuties.TTL,
@@ -57,21 +76,46 @@ func main() {
         zap.L().Fatal("init sync duties cache", zap.Error(err))
     }
 
-    sdUC := usecase.NewSyncDutiesUseCase(consClient, cache_duties)
+    altairForkEpoch, err := consensus.AltairForkEpoch(cfg.Execution.Network)
+    if err != nil {
+        zap.L().Fatal("resolve altair fork epoch", zap.Error(err))
+    }
 
-    ethHTTP, err := ethclient.Dial(cfg.Ethereum.RPCHTTP)
+    cache_proposers, err := consensus.NewProposerCache(
+        cfg.Cache.Proposer.MaxEntries,
+        cfg.Cache.Proposer.TTL,
+    )
     if err != nil {
-        zap.L().Fatal("dial ethclient", zap.Error(err))
+        zap.L().Fatal("init proposer cache", zap.Error(err))
     }
-    rpcHTTP, err := rpc.DialHTTP(cfg.Ethereum.RPCHTTP)
+
+    ppUC := usecase.NewProposerUseCase(consClient, consClient, cache_proposers, headTracker)
+
+    sdUC := usecase.NewSyncDutiesUseCase(ppUC, consClient, cache_duties, headTracker, consClient, altairForkEpoch)
+    vsUC := usecase.NewValidatorSyncDutyUseCase(sdUC, consClient, consClient)
+    spUC := usecase.NewSyncParticipationUseCase(consClient, sdUC)
+    vlUC := usecase.NewValidatorStatusUseCase(consClient, headTracker)
+
+    execHeaders := make(stdhttp.Header, len(cfg.Execution.Headers))
+    for k, v := range cfg.Execution.Headers {
+        execHeaders.Set(k, v)
+    }
+    rpcHTTP, err := rpc.DialOptions(context.Background(), cfg.Execution.HTTP, rpc.WithHeaders(execHeaders))
     if err != nil {
         zap.L().Fatal("dial rpc", zap.Error(err))
     }
+    ethHTTP := ethclient.NewClient(rpcHTTP)
+
+    builders, err := builder.NewRegistry(cfg.Builders.RegistryFile, cfg.Builders.PollInterval)
+    if err != nil {
+        zap.L().Fatal("load builder registry", zap.Error(err))
+    }
+    go builders.Run(trackerCtx)
 
     execClient, err := execution.NewExecutionClient(
         rpcHTTP,
         ethHTTP,
-        cfg.Ethereum.MevRelays,
+        builders,
         cfg.Retry.BlockReward.MaxRetries,
         cfg.Retry.BlockReward.Backoff,
     )
@@ -87,9 +131,70 @@ func main() {
         zap.L().Fatal("init sync duties cache", zap.Error(err))
     }
 
-    brUC := usecase.NewBlockRewardUseCase(execClient, cache_reward)
+    relayClient, err := relay.NewRelayClient(
+        cfg.Ethereum.MevRelays,
+        cfg.Retry.BlockReward.MaxRetries,
+        cfg.Retry.BlockReward.Backoff,
+        cfg.Retry.BlockReward.Timeout,
+    )
+    if err != nil {
+        zap.L().Fatal("init relay client", zap.Error(err))
+    }
+
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, headTracker, relayClient, ppUC)
+
+    cache_fees, err := execution.NewBlockFeesCache(
+        cfg.Cache.BlockFees.MaxEntries,
+        cfg.Cache.BlockFees.TTL,
+    )
+    if err != nil {
+        zap.L().Fatal("init block fees cache", zap.Error(err))
+    }
+
+    bfUC := usecase.NewBlockFeesUseCase(consClient, execClient, cache_fees, headTracker)
+
+    cache_cl_rewards, err := consensus.NewConsensusRewardCache(
+        cfg.Cache.ConsensusRewards.MaxEntries,
+        cfg.Cache.ConsensusRewards.TTL,
+    )
+    if err != nil {
+        zap.L().Fatal("init consensus rewards cache", zap.Error(err))
+    }
+
+    prUC := usecase.NewProposalRewardUseCase(brUC, consClient, cache_cl_rewards)
+
+    cache_attestations, err := consensus.NewAttestationRewardsCache(
+        cfg.Cache.AttestationRewards.MaxEntries,
+        cfg.Cache.AttestationRewards.TTL,
+    )
+    if err != nil {
+        zap.L().Fatal("init attestation rewards cache", zap.Error(err))
+    }
+
+    arUC := usecase.NewAttestationRewardsUseCase(consClient, cache_attestations, headTracker)
+
+    cache_sync_rewards, err := consensus.NewSyncCommitteeRewardsCache(
+        cfg.Cache.SyncRewards.MaxEntries,
+        cfg.Cache.SyncRewards.TTL,
+    )
+    if err != nil {
+        zap.L().Fatal("init sync rewards cache", zap.Error(err))
+    }
 
-    r := httpPkg.NewRouter(cfg, brUC, sdUC)
+    srUC := usecase.NewSyncRewardsUseCase(consClient, consClient, consClient, cache_sync_rewards, headTracker)
+
+    r := httpPkg.NewRouter(cfg, handler.UseCases{
+        BlockReward:    brUC,
+        SyncDuties:     sdUC,
+        BlockFees:      bfUC,
+        ProposalReward: prUC,
+        Attestations:   arUC,
+        SyncRewards:    srUC,
+        Proposer:       ppUC,
+        SyncDuty:       vsUC,
+        Participation:  spUC,
+        Validator:      vlUC,
+    })
 
     srv := &stdhttp.Server{
         Addr:    cfg.Server.Address,
@@ -107,6 +212,7 @@ func main() {
     <-stop
 
     zap.L().Info("shutting down…")
+    stopTracker()
     ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
     defer cancel()
     if err := srv.Shutdown(ctx); err != nil {
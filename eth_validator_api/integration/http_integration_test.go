@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -10,14 +11,21 @@ import (
 	"io"
 	"bytes"
 	"strings"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-chi/chi"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	
 
+	"eth_validator_api/internal/adapter/builder"
 	"eth_validator_api/internal/adapter/consensus"
 	"eth_validator_api/internal/adapter/execution"
+	"eth_validator_api/internal/adapter/relay"
 	"eth_validator_api/internal/handler"
 	"eth_validator_api/internal/usecase"
 	"eth_validator_api/internal/domain"
@@ -34,15 +42,49 @@ func mockQuickNode() *httptest.Server {
 
         w.Header().Set("Content-Type", "application/json")
         json.NewEncoder(w).Encode(map[string]interface{}{
-            "data": map[string][]string{"validators": {"AAA", "BBB"}},
+            "data": map[string]interface{}{
+                "validators":           []string{"7", "8"},
+                "validator_aggregates": [][]string{{"7"}, {"8"}},
+            },
         })
     })
 
+    mockHead(mux, "101")
+
+    mux.HandleFunc("/eth/v2/beacon/blocks/100", func(w http.ResponseWriter, r *http.Request) {
+        log.Printf("[MOCK] REST GET %s", r.URL.Path)
+        w.Header().Set("Content-Type", "application/json")
+        io.WriteString(w, beaconBlockJSON("100", "100"))
+    })
+
+    mux.HandleFunc("/eth/v2/beacon/blocks/101", func(w http.ResponseWriter, r *http.Request) {
+        log.Printf("[MOCK] REST GET %s", r.URL.Path)
+        w.Header().Set("Content-Type", "application/json")
+        io.WriteString(w, beaconBlockJSON("101", "101"))
+    })
+
+    mux.HandleFunc("/eth/v1/beacon/rewards/blocks/101", func(w http.ResponseWriter, r *http.Request) {
+        log.Printf("[MOCK] REST GET %s", r.URL.Path)
+        w.Header().Set("Content-Type", "application/json")
+        io.WriteString(w, `{"execution_optimistic":false,"finalized":true,"data":{"proposer_index":"1","total":"40000000",`+
+            `"attestations":"36000000","sync_aggregate":"4000000","proposer_slashings":"0","attester_slashings":"0"}}`)
+    })
+
+    mux.HandleFunc("/eth/v1/beacon/states/head/validators/1", func(w http.ResponseWriter, r *http.Request) {
+        log.Printf("[MOCK] REST GET %s", r.URL.Path)
+        io.WriteString(w, `{"data":{"index":"1","validator":{"pubkey":"`+mockProposerPubkey+`"}}}`)
+    })
+
     mux.HandleFunc("/eth/v1/beacon/states/100/validators", func(w http.ResponseWriter, r *http.Request) {
-        log.Printf("[MOCK] REST GET %s?%s", r.URL.Path, r.URL.RawQuery)
-        ids := r.URL.Query().Get("id")
-        log.Printf("[MOCK]   ids: %s", ids)
-        parts := strings.Split(ids, ",")
+        log.Printf("[MOCK] REST %s %s", r.Method, r.URL.Path)
+        var req struct {
+            IDs []string `json:"ids"`
+        }
+        if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+            w.WriteHeader(http.StatusBadRequest)
+            return
+        }
+        log.Printf("[MOCK]   ids: %s", strings.Join(req.IDs, ","))
         type valEntry struct {
             Index     string `json:"index"`
             Validator struct {
@@ -50,7 +92,7 @@ func mockQuickNode() *httptest.Server {
             } `json:"validator"`
         }
         var data []valEntry
-        for _, idx := range parts {
+        for _, idx := range req.IDs {
             e := valEntry{Index: idx}
             e.Validator.Pubkey = idx
             data = append(data, e)
@@ -89,7 +131,7 @@ func mockQuickNode() *httptest.Server {
                     rep["result"] = "0x64"
                 case "eth_getBalance":
                     rep["result"] = "0xde0b6b3a7640000"
-                case "eth_getBlockByNumber", "eth_getHeaderByNumber":
+                case "eth_getBlockByHash":
                     blk := map[string]interface{}{
                         "difficulty":   "0x480676368",
                         "extraData":    "0x476574682f76312e302e302f6c696e75782f676f312e342e32",
@@ -100,7 +142,7 @@ func mockQuickNode() *httptest.Server {
                         "miner":        "0x28921e4e2C9d84F4c0f0C0cEb991f45751a0fe93",
                         "mixHash":      "0x81434e7b287e3a3bfb45c5a62f8b84795187242d2b8b059426cc7742097f12a2",
                         "nonce":        "0x7098a77b4363303d",
-                        "number":       req.Params[0].(string),
+                        "number":       "0x64",
                         "parentHash":   "0xc6319dc266cc65771870a9d04800ecc7c624d481e1ff0d6368be5ec2f09b3ff9",
                         "receiptsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
                         "sha3Uncles":   "0x139492965079f29bdf1f4765d7892da50cb0cc85c8ea3641718ec2b8f60526b5",
@@ -140,8 +182,31 @@ func mockQuickNode() *httptest.Server {
             rep["result"] = "0x64"
         case "eth_getBalance":
             rep["result"] = "0xde0b6b3a7640000"
-        case "eth_getBlockByNumber", "eth_getHeaderByNumber":
-            rep["result"] = map[string]interface{}{
+        case "eth_getBlockReceipts":
+            rep["result"] = []map[string]interface{}{
+                {"from": "0x1111111111111111111111111111111111111111", "gasUsed": "0x5208", "effectiveGasPrice": "0x3b9aca00"},
+                {"from": mockMiner, "gasUsed": "0x5208", "effectiveGasPrice": "0x77359400", "blobGasUsed": "0x20000", "blobGasPrice": "0x1"},
+                {"from": "0x1111111111111111111111111111111111111111", "gasUsed": "0x5208", "effectiveGasPrice": "0x0"},
+            }
+        case "debug_traceBlockByHash":
+            rep["result"] = []map[string]interface{}{
+                {"txHash": "0x01", "result": map[string]interface{}{
+                    "type": "CALL", "from": "0x1111111111111111111111111111111111111111", "to": mockMiner, "value": "0x470de4df820000",
+                }},
+                {"txHash": "0x02", "result": map[string]interface{}{
+                    "type": "CALL", "from": mockMiner, "to": mockFeeRecipient, "value": "0xb1a2bc2ec50000",
+                }},
+                {"txHash": "0x03", "result": map[string]interface{}{
+                    "type": "CALL", "from": "0x1111111111111111111111111111111111111111", "to": "0x3333333333333333333333333333333333333333", "value": "0x0",
+                    "calls": []map[string]interface{}{
+                        {"type": "CALL", "from": "0x3333333333333333333333333333333333333333", "to": mockMiner, "value": "0x2386f26fc10000"},
+                        {"type": "DELEGATECALL", "from": "0x3333333333333333333333333333333333333333", "to": mockMiner, "value": "0x6f05b59d3b20000"},
+                        {"type": "CALL", "from": "0x3333333333333333333333333333333333333333", "to": mockMiner, "value": "0xde0b6b3a7640000", "error": "execution reverted"},
+                    },
+                }},
+            }
+        case "eth_getBlockByHash":
+            blk := map[string]interface{}{
                 "difficulty":   "0x480676368",
                 "extraData":    "0x476574682f76312e302e302f6c696e75782f676f312e342e32",
                 "gasLimit":     "0x1388",
@@ -151,7 +216,7 @@ func mockQuickNode() *httptest.Server {
                 "miner":        "0x28921e4e2C9d84F4c0f0C0cEb991f45751a0fe93",
                 "mixHash":      "0x81434e7b287e3a3bfb45c5a62f8b84795187242d2b8b059426cc7742097f12a2",
                 "nonce":        "0x7098a77b4363303d",
-                "number":       req.Params[0].(string),
+                "number":       "0x64",
                 "parentHash":   "0xc6319dc266cc65771870a9d04800ecc7c624d481e1ff0d6368be5ec2f09b3ff9",
                 "receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
                 "sha3Uncles":"0x139492965079f29bdf1f4765d7892da50cb0cc85c8ea3641718ec2b8f60526b5",
@@ -162,6 +227,13 @@ func mockQuickNode() *httptest.Server {
                 "transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
                 "uncles":[]interface{}{"0x9022b8f085f8ae4f6d808213ed42d7edddc7ca61fe6092ef7b9f3ac2b62266d2"},
             }
+            if len(req.Params) > 1 && req.Params[1] == true {
+                blk["transactions"] = []map[string]interface{}{
+                    {"hash": "0x01", "from": "0x1111111111111111111111111111111111111111", "to": mockMiner, "value": "0x0"},
+                    {"hash": "0x02", "from": mockMiner, "to": mockFeeRecipient, "value": "0xb1a2bc2ec50000"},
+                }
+            }
+            rep["result"] = blk
         default:
             rep["result"] = nil
         }
@@ -172,6 +244,65 @@ func mockQuickNode() *httptest.Server {
 }
 
 
+const (
+    mockProposerPubkey = "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+    mockMiner          = "0x28921e4e2C9d84F4c0f0C0cEb991f45751a0fe93"
+    mockFeeRecipient   = "0xFEE0000000000000000000000000000000000001"
+    mockBlockHash      = "0xfeebb1c60ceca18290b0f20aa581d34d293e240fcb6ccb5ee283c007dd5814e2"
+    mockParentHash     = "0xc6319dc266cc65771870a9d04800ecc7c624d481e1ff0d6368be5ec2f09b3ff9"
+)
+
+func mockHead(mux *http.ServeMux, slot string) {
+    mux.HandleFunc("/eth/v1/beacon/headers/head", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"header":{"message":{"slot":"`+slot+`"}}}}`)
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/head/finality_checkpoints", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"previous_justified":{"epoch":"0"},"current_justified":{"epoch":"1"},"finalized":{"epoch":"0"}}}`)
+    })
+}
+
+// newProposers builds the proposer usecase sync duties resolve slots through.
+func newProposers(consClient *consensus.ConsensusClient, head *consensus.HeadTracker) *usecase.ProposerUseCase {
+    cache, _ := consensus.NewProposerCache(10, time.Minute)
+    return usecase.NewProposerUseCase(consClient, consClient, cache, head)
+}
+
+// mockHeaders serves the block headers given by slot or root and answers 404
+// for any other block.
+func mockHeaders(mux *http.ServeMux, headers map[string]string) {
+    mux.HandleFunc("/eth/v1/beacon/headers/", func(w http.ResponseWriter, r *http.Request) {
+        header, ok := headers[strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/headers/")]
+        if !ok {
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        io.WriteString(w, header)
+    })
+}
+
+func headerJSON(slot, parentRoot string) string {
+    return `{"data":{"header":{"message":{"slot":"` + slot + `","parent_root":"` + parentRoot + `"}}}}`
+}
+
+func newHeadTracker(t *testing.T, consClient *consensus.ConsensusClient) *consensus.HeadTracker {
+    t.Helper()
+    tracker := consensus.NewHeadTracker(consClient, time.Second)
+    if err := tracker.Refresh(context.Background()); err != nil {
+        t.Fatalf("HeadTracker.Refresh: %v", err)
+    }
+    return tracker
+}
+
+func beaconBlockJSON(slot, blockNumber string) string {
+    return `{"version":"deneb","execution_optimistic":false,"finalized":true,"data":{"message":{` +
+        `"slot":"` + slot + `","proposer_index":"1","body":{` +
+        `"graffiti":"0x4c69676874686f7573652f76352e332e30000000000000000000000000000000",` +
+        `"sync_aggregate":{"sync_committee_bits":"0x01"},"execution_payload":{` +
+        `"block_number":"` + blockNumber + `",` +
+        `"block_hash":"` + mockBlockHash + `",` +
+        `"fee_recipient":"` + mockMiner + `"}}}}}`
+}
+
 func TestIntegration_BlockRewardAndSyncDuties(t *testing.T) {
 	mock := mockQuickNode()
 	defer mock.Close()
@@ -187,7 +318,7 @@ func TestIntegration_BlockRewardAndSyncDuties(t *testing.T) {
 
 	execClient, err := execution.NewExecutionClient(
 		rpcHTTP, ethHTTP,
-		[]string{},       
+		nil,
 		3,               
 		100*time.Millisecond,
 	)
@@ -195,15 +326,9 @@ func TestIntegration_BlockRewardAndSyncDuties(t *testing.T) {
 		t.Fatalf("NewExecutionClient: %v", err)
 	}
 
-    cache_reward, _ := execution.NewBlockRewardCache(
-        128,
-        60*time.Second, 
-    )
-	brUC := usecase.NewBlockRewardUseCase(execClient, cache_reward)
-
-
 	consClient, err := consensus.NewConsensusClient(    
 		mock.URL,       
+		nil,
 		3,              
 		100*time.Millisecond,
 		5*time.Second,  
@@ -211,16 +336,23 @@ func TestIntegration_BlockRewardAndSyncDuties(t *testing.T) {
 	if err != nil {
 		t.Fatalf("NewConsensusClient: %v", err)
 	}
+
+    cache_reward, _ := execution.NewBlockRewardCache(
+        128,
+        60*time.Second, 
+    )
+	headTracker := newHeadTracker(t, consClient)
+	brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, headTracker, nil, nil)
 	
 	cache, _ := consensus.NewSyncDutiesCache(
         128,
         60*time.Second, 
     )
 
-	sdUC := usecase.NewSyncDutiesUseCase(consClient, cache)
+	sdUC := usecase.NewSyncDutiesUseCase(newProposers(consClient, headTracker), consClient, cache, headTracker, nil, 0)
 
 	r := chi.NewRouter()
-	h := handler.NewHandler(brUC, sdUC)
+	h := handler.NewHandler(handler.UseCases{BlockReward: brUC, SyncDuties: sdUC})
 	h.Register(r)
 
 	
@@ -237,6 +369,12 @@ func TestIntegration_BlockRewardAndSyncDuties(t *testing.T) {
 	if br.Status == "" {
 		t.Errorf("esperaba status no vacío, got '%s'", br.Status)
 	}
+	if br.Slot != 100 || br.BlockNumber != 100 {
+		t.Errorf("slot/bloque inesperados: %+v", br)
+	}
+	if !br.Finalized || br.ExecutionOptimistic {
+		t.Errorf("flags de finalidad inesperados: %+v", br.Finality)
+	}
 
 
 	rec2 := httptest.NewRecorder()
@@ -249,9 +387,13 @@ func TestIntegration_BlockRewardAndSyncDuties(t *testing.T) {
 	if err := json.NewDecoder(rec2.Body).Decode(&sd); err != nil {
 		t.Fatalf("decoding syncduties: %v", err)
 	}
-	if len(sd.Validators) != 2 || sd.Validators[0] != "AAA" {
+	if len(sd.Validators) != 2 || sd.Validators[0] != "7" {
 		t.Errorf("syncduties mismatch: %+v", sd.Validators)
 	}
+	if len(sd.Subcommittees) != 2 || sd.Subcommittees[1].Subnet != 1 ||
+		len(sd.Subcommittees[1].Members) != 1 || sd.Subcommittees[1].Members[0] != (domain.SyncCommitteeMember{ValidatorIndex: 8, Pubkey: "8"}) {
+		t.Errorf("subcomités inesperados: %+v", sd.Subcommittees)
+	}
 }
 
 
@@ -292,9 +434,18 @@ func TestIntegration_SyncDuties_ErrorScenarios(t *testing.T) {
                     io.WriteString(w, tc.body)
                 }
             })
-            mux.HandleFunc("/eth/v1/beacon/states/100/validators", func(w http.ResponseWriter, r *http.Request) {
+            mux.HandleFunc("/eth/v1/beacon/states/head/validators/1", func(w http.ResponseWriter, r *http.Request) {
+        log.Printf("[MOCK] REST GET %s", r.URL.Path)
+        io.WriteString(w, `{"data":{"index":"1","validator":{"pubkey":"`+mockProposerPubkey+`"}}}`)
+    })
+
+    mux.HandleFunc("/eth/v1/beacon/states/100/validators", func(w http.ResponseWriter, r *http.Request) {
                 json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}})
             })
+            mux.HandleFunc("/eth/v2/beacon/blocks/100", func(w http.ResponseWriter, r *http.Request) {
+                io.WriteString(w, beaconBlockJSON("100", "100"))
+            })
+            mockHead(mux, "100")
             mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
                 var req struct {
                     Method string `json:"method"`
@@ -312,15 +463,16 @@ func TestIntegration_SyncDuties_ErrorScenarios(t *testing.T) {
 
             ethHTTP, _ := ethclient.Dial(server.URL)
             rpcHTTP, _ := rpc.DialHTTP(server.URL)
-            execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, []string{}, 1, 10*time.Millisecond)
+            execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, nil, 1, 10*time.Millisecond)
+            consClient, _ := consensus.NewConsensusClient(server.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+            headTracker := newHeadTracker(t, consClient)
             cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
-            brUC := usecase.NewBlockRewardUseCase(execClient, cache_reward)
-            consClient, _ := consensus.NewConsensusClient(server.URL, 1, 10*time.Millisecond, 1*time.Second)
+            brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, headTracker, nil, nil)
             cache, _ := consensus.NewSyncDutiesCache(10, time.Minute)
-            sdUC := usecase.NewSyncDutiesUseCase(consClient, cache)
+            sdUC := usecase.NewSyncDutiesUseCase(newProposers(consClient, headTracker), consClient, cache, headTracker, nil, 0)
 
             r := chi.NewRouter()
-            h := handler.NewHandler(brUC, sdUC)
+            h := handler.NewHandler(handler.UseCases{BlockReward: brUC, SyncDuties: sdUC})
             h.Register(r)
 
             rec := httptest.NewRecorder()
@@ -336,27 +488,46 @@ func TestIntegration_SyncDuties_ErrorScenarios(t *testing.T) {
 func TestIntegration_BlockReward_ErrorScenarios(t *testing.T) {
     cases := []struct {
         name           string
-        mockBlockNum   string
+        headSlot       string
         headerStatus   int
+        blockHash      string
         wantStatusCode int
     }{
         {
             name:           "SlotInFuture",
-            mockBlockNum:   "0x0",  
+            headSlot:       "50",
             headerStatus:   200,
             wantStatusCode: http.StatusBadRequest,
         },
         {
             name:           "HeaderNotFound",
-            mockBlockNum:   "0x64", 
+            headSlot:       "100",
             headerStatus:   500,   
             wantStatusCode: http.StatusNotFound,
         },
+        {
+            // The execution node doesn't have the block the beacon chain
+            // committed to, e.g. it lags or sits on another fork.
+            name:           "BlockHashUnknown",
+            headSlot:       "100",
+            headerStatus:   200,
+            blockHash:      "0x" + strings.Repeat("ab", 32),
+            wantStatusCode: http.StatusNotFound,
+        },
     }
 
     for _, tc := range cases {
         t.Run(tc.name, func(t *testing.T) {
             mux := http.NewServeMux()
+            mux.HandleFunc("/eth/v2/beacon/blocks/100", func(w http.ResponseWriter, r *http.Request) {
+                w.Header().Set("Content-Type", "application/json")
+                body := beaconBlockJSON("100", "100")
+                if tc.blockHash != "" {
+                    body = strings.Replace(body, mockBlockHash, tc.blockHash, 1)
+                }
+                io.WriteString(w, body)
+            })
+            mockHead(mux, tc.headSlot)
             mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
                 var req struct {
                     Method string        `json:"method"`
@@ -367,17 +538,15 @@ func TestIntegration_BlockReward_ErrorScenarios(t *testing.T) {
                 w.Header().Set("Content-Type", "application/json")
 
                 switch req.Method {
-                case "eth_blockNumber":
-                    json.NewEncoder(w).Encode(map[string]interface{}{
-                        "jsonrpc": "2.0", "id": req.ID, "result": tc.mockBlockNum,
-                    })
-                case "eth_getBlockByNumber", "eth_getHeaderByNumber":
+                case "eth_getBlockByHash":
                     w.WriteHeader(tc.headerStatus)
                     if tc.headerStatus == 200 {
-                        num := req.Params[0].(string)
+                        var result interface{}
+                        if req.Params[0] == mockBlockHash {
+                            result = map[string]interface{}{"number": "0x64", "miner": "0x00"}
+                        }
                         json.NewEncoder(w).Encode(map[string]interface{}{
-                            "jsonrpc": "2.0", "id": req.ID,
-                            "result": map[string]interface{}{"number": num, "miner": "0x00"},
+                            "jsonrpc": "2.0", "id": req.ID, "result": result,
                         })
                     }
                 default:
@@ -392,12 +561,13 @@ func TestIntegration_BlockReward_ErrorScenarios(t *testing.T) {
 
             ethHTTP, _ := ethclient.Dial(server.URL)
             rpcHTTP, _ := rpc.DialHTTP(server.URL)
-            execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, []string{}, 1, 10*time.Millisecond)
+            execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, nil, 1, 10*time.Millisecond)
+            consClient, _ := consensus.NewConsensusClient(server.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
             cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
-            brUC := usecase.NewBlockRewardUseCase(execClient, cache_reward)
+            brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
 
             r := chi.NewRouter()
-            h := handler.NewHandler(brUC, usecase.NewSyncDutiesUseCase(nil, nil))
+            h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
             h.Register(r)
 
             rec := httptest.NewRecorder()
@@ -409,3 +579,1205 @@ func TestIntegration_BlockReward_ErrorScenarios(t *testing.T) {
         })
     }
 }
+
+func TestIntegration_MissedSlot(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/eth/v2/beacon/blocks/101", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusNotFound)
+        io.WriteString(w, `{"code":404,"message":"NOT_FOUND: beacon block at slot 101"}`)
+    })
+    mux.HandleFunc("/eth/v2/beacon/blocks/500", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusNotFound)
+    })
+    mux.HandleFunc("/eth/v2/beacon/blocks/40", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusNotFound)
+    })
+    mockHead(mux, "200")
+    // Slot 101 lies between blocks 100 and 102; the node's history starts at
+    // block 41, whose parent it never stored.
+    mockHeaders(mux, map[string]string{
+        "102":    headerJSON("102", "0xb100"),
+        "0xb100": headerJSON("100", "0xb099"),
+        "41":     headerJSON("41", "0xb040"),
+    })
+    mux.HandleFunc("/eth/v1/validator/duties/proposer/1", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":[{"pubkey":"0xcc","validator_index":"56","slot":"40"}]}`)
+    })
+    mux.HandleFunc("/eth/v1/validator/duties/proposer/3", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":[{"pubkey":"0xaa","validator_index":"54","slot":"100"},{"pubkey":"0xbb","validator_index":"55","slot":"101"}]}`)
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/101/sync_committees", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"validators":[]}}`)
+    })
+    mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+        var req struct {
+            Method string `json:"method"`
+            ID     int    `json:"id"`
+        }
+        _ = json.NewDecoder(r.Body).Decode(&req)
+        json.NewEncoder(w).Encode(map[string]interface{}{
+            "jsonrpc": "2.0", "id": req.ID, "result": "0xc8",
+        })
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+
+    ethHTTP, _ := ethclient.Dial(server.URL)
+    rpcHTTP, _ := rpc.DialHTTP(server.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(server.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    cache, _ := consensus.NewSyncDutiesCache(10, time.Minute)
+    headTracker := newHeadTracker(t, consClient)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, headTracker, nil, nil)
+    sdUC := usecase.NewSyncDutiesUseCase(newProposers(consClient, headTracker), consClient, cache, headTracker, nil, 0)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC, SyncDuties: sdUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/101", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("blockreward status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var br domain.BlockReward
+    if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+        t.Fatalf("decoding blockreward: %v", err)
+    }
+    if br.Status != domain.SlotStatusMissed || br.ProposerIndex != 55 {
+        t.Errorf("esperaba slot perdido con proposer 55, got %+v", br)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/syncduties/101", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("syncduties status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var sd domain.SyncDuties
+    if err := json.NewDecoder(rec.Body).Decode(&sd); err != nil {
+        t.Fatalf("decoding syncduties: %v", err)
+    }
+    if sd.Status != domain.SlotStatusMissed || sd.ProposerIndex != 55 {
+        t.Errorf("esperaba slot perdido con proposer 55, got %+v", sd)
+    }
+
+    // A 404 for a block before the node's history isn't a missed slot.
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/40", nil))
+    if rec.Code != http.StatusNotFound {
+        t.Fatalf("slot fuera del historial: status = %d, want 404 (body=%s)", rec.Code, rec.Body.String())
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/500", nil))
+    if rec.Code != http.StatusBadRequest {
+        t.Fatalf("slot futuro: status = %d, want 400", rec.Code)
+    }
+}
+
+
+func TestIntegration_HeadTrackerFollowsEvents(t *testing.T) {
+    mux := http.NewServeMux()
+    mockHead(mux, "100")
+    mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+        if got := r.URL.Query().Get("topics"); got != "head,finalized_checkpoint" {
+            t.Errorf("topics inesperados: %s", got)
+        }
+        w.Header().Set("Content-Type", "text/event-stream")
+        io.WriteString(w, "event: head\ndata: {\"slot\":\"105\",\"epoch_transition\":false}\n\n")
+        io.WriteString(w, "event: finalized_checkpoint\ndata: {\"epoch\":\"2\"}\n\n")
+        w.(http.Flusher).Flush()
+        <-r.Context().Done()
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+
+    consClient, _ := consensus.NewConsensusClient(server.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    tracker := consensus.NewHeadTracker(consClient, time.Second)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    go tracker.Run(ctx)
+
+    deadline := time.Now().Add(5 * time.Second)
+    for time.Now().Before(deadline) {
+        if head, ok := tracker.Head(); ok && head.HeadSlot == 105 {
+            if head.JustifiedSlot != 32 {
+                t.Errorf("justified slot = %d, want 32", head.JustifiedSlot)
+            }
+            return
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    head, _ := tracker.Head()
+    t.Fatalf("el tracker no siguió el evento head: %+v", head)
+}
+
+func TestIntegration_HeadTrackerPollsWhenStreamHangs(t *testing.T) {
+    var slot atomic.Value
+    slot.Store("100")
+    mux := http.NewServeMux()
+    mux.HandleFunc("/eth/v1/beacon/headers/head", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"header":{"message":{"slot":"`+slot.Load().(string)+`"}}}}`)
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/head/finality_checkpoints", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"previous_justified":{"epoch":"0"},"current_justified":{"epoch":"1"},"finalized":{"epoch":"0"}}}`)
+    })
+    // Accepts the connection but never sends the response headers.
+    mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+        <-r.Context().Done()
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+
+    consClient, _ := consensus.NewConsensusClient(server.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    tracker := consensus.NewHeadTracker(consClient, 50*time.Millisecond)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    go tracker.Run(ctx)
+
+    slot.Store("107")
+    deadline := time.Now().Add(5 * time.Second)
+    for time.Now().Before(deadline) {
+        if head, ok := tracker.Head(); ok && head.HeadSlot == 107 {
+            return
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    head, _ := tracker.Head()
+    t.Fatalf("el tracker no pasó a sondear con el stream colgado: %+v", head)
+}
+
+func TestIntegration_BeaconAuthHeaders(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/eth/v1/beacon/headers/head", func(w http.ResponseWriter, r *http.Request) {
+        if r.Header.Get("Authorization") != "Bearer secret" {
+            w.WriteHeader(http.StatusUnauthorized)
+            return
+        }
+        io.WriteString(w, `{"data":{"header":{"message":{"slot":"100"}}}}`)
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/head/finality_checkpoints", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"current_justified":{"epoch":"2"},"finalized":{"epoch":"1"}}}`)
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+
+    consClient, _ := consensus.NewConsensusClient(
+        server.URL+"/",
+        map[string]string{"Authorization": "Bearer secret"},
+        1, 10*time.Millisecond, 1*time.Second,
+    )
+    head, _ := newHeadTracker(t, consClient).Head()
+    if head.HeadSlot != 100 || head.FinalizedSlot != 32 {
+        t.Errorf("head inesperado: %+v", head)
+    }
+}
+
+func TestIntegration_BlockRewardMethods(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+
+    ethHTTP, _ := ethclient.Dial(mock.URL)
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
+    h.Register(r)
+
+    get := func(url string) domain.BlockReward {
+        rec := httptest.NewRecorder()
+        r.ServeHTTP(rec, httptest.NewRequest("GET", url, nil))
+        if rec.Code != http.StatusOK {
+            t.Fatalf("%s: status = %d, want 200 (body=%s)", url, rec.Code, rec.Body.String())
+        }
+        var br domain.BlockReward
+        if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+            t.Fatalf("%s: decoding: %v", url, err)
+        }
+        return br
+    }
+
+    // 21000 × 1 gwei + 21000 × 2 gwei, with no base fee in the mocked header.
+    receipts := get("/blockreward/100?method=receipts")
+    if receipts.Method != domain.RewardMethodReceipts || receipts.RewardGwei != "63000" || receipts.RewardWei != "63000000000000" {
+        t.Errorf("receipts inesperado: %+v", receipts)
+    }
+
+    balance := get("/blockreward/100")
+    if balance.Method != domain.RewardMethodBalance || balance.RewardWei != "0" {
+        t.Errorf("balance inesperado: %+v", balance)
+    }
+
+    auto := get("/blockreward/100?method=auto")
+    if auto.Method != domain.RewardMethodReceipts || auto.CrossCheck == nil {
+        t.Fatalf("auto inesperado: %+v", auto)
+    }
+    if auto.CrossCheck.Match || auto.CrossCheck.BalanceWei != "0" || auto.CrossCheck.ReceiptsWei != "63000000000000" {
+        t.Errorf("cross check inesperado: %+v", auto.CrossCheck)
+    }
+}
+
+func TestIntegration_BlockReward_ProposerPayment(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+
+    registryPath := filepath.Join(t.TempDir(), "builders.json")
+    os.WriteFile(registryPath, []byte(`{"builders":[{"name":"Mock Builder","fee_recipients":["`+mockMiner+`"]}]}`), 0o644)
+    builders, err := builder.NewRegistry(registryPath, time.Minute)
+    if err != nil {
+        t.Fatalf("NewRegistry: %v", err)
+    }
+
+    ethHTTP, _ := ethclient.Dial(mock.URL)
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, builders, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/101", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var br domain.BlockReward
+    if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+        t.Fatalf("decoding blockreward: %v", err)
+    }
+    if br.Status != "mev" || br.ProposerPaymentWei != "50000000000000000" || br.ProposerPaymentTx != "0x02" {
+        t.Errorf("pago al proposer inesperado: %+v", br)
+    }
+    if !strings.EqualFold(br.BuilderAddress, mockMiner) || !strings.EqualFold(br.FeeRecipient, mockFeeRecipient) {
+        t.Errorf("direcciones inesperadas: builder=%s fee_recipient=%s", br.BuilderAddress, br.FeeRecipient)
+    }
+    if br.Builder != "Mock Builder" {
+        t.Errorf("esperaba identificar el builder, got %q", br.Builder)
+    }
+}
+
+func TestIntegration_BlockReward_Trace(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+
+    ethHTTP, _ := ethclient.Dial(mock.URL)
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/101?method=trace", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var br domain.BlockReward
+    if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+        t.Fatalf("decoding blockreward: %v", err)
+    }
+    if br.Method != domain.RewardMethodTrace || br.Breakdown == nil {
+        t.Fatalf("esperaba desglose por trazas: %+v", br)
+    }
+    // Tips of 21000 + 42000 gwei, less the 42000 gwei and 131072 wei of blob
+    // gas the builder paid for its own payment transaction. Delegate and
+    // reverted calls move nothing.
+    want := domain.CoinbaseBreakdown{
+        FeesWei:            "20999999868928",
+        DirectTransfersWei: "20000000000000000",
+        InternalCallsWei:   "10000000000000000",
+        ProposerPaymentWei: "-50000000000000000",
+    }
+    if *br.Breakdown != want {
+        t.Errorf("desglose = %+v, esperaba %+v", *br.Breakdown, want)
+    }
+    if br.RewardWei != "-19979000000131072" {
+        t.Errorf("reward = %s, esperaba -19979000000131072", br.RewardWei)
+    }
+}
+
+func TestIntegration_BlockReward_TraceUnsupported(t *testing.T) {
+    var traceCalls int
+    var mu sync.Mutex
+    mock := mockQuickNode()
+    defer mock.Close()
+    // Only the trace call is answered here; everything else goes to the
+    // regular mock.
+    proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        raw, _ := io.ReadAll(r.Body)
+        var req struct {
+            Method string `json:"method"`
+            ID     int    `json:"id"`
+        }
+        if json.Unmarshal(raw, &req) == nil && req.Method == "debug_traceBlockByHash" {
+            mu.Lock()
+            traceCalls++
+            mu.Unlock()
+            io.WriteString(w, `{"jsonrpc":"2.0","id":`+strconv.Itoa(req.ID)+`,"error":{"code":-32601,"message":"the method debug_traceBlockByHash does not exist/is not available"}}`)
+            return
+        }
+        resp, err := http.Post(mock.URL+r.URL.Path, "application/json", bytes.NewReader(raw))
+        if err != nil {
+            w.WriteHeader(http.StatusBadGateway)
+            return
+        }
+        defer resp.Body.Close()
+        w.WriteHeader(resp.StatusCode)
+        io.Copy(w, resp.Body)
+    }))
+    defer proxy.Close()
+
+    rpcHTTP, _ := rpc.DialHTTP(proxy.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethclient.NewClient(rpcHTTP), nil, 3, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/101?method=trace", nil))
+    if rec.Code != http.StatusNotImplemented {
+        t.Fatalf("status = %d, want 501 (body=%s)", rec.Code, rec.Body.String())
+    }
+    if traceCalls != 1 {
+        t.Errorf("esperaba una sola llamada de trazas sin reintentos, got %d", traceCalls)
+    }
+}
+
+func TestIntegration_Proposer(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+
+    ethHTTP, _ := ethclient.Dial(mock.URL)
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    headTracker := newHeadTracker(t, consClient)
+    cache_proposers, _ := consensus.NewProposerCache(10, time.Minute)
+    ppUC := usecase.NewProposerUseCase(consClient, consClient, cache_proposers, headTracker)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, headTracker, nil, ppUC)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC, Proposer: ppUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/proposer/101", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var p domain.ProposerIdentity
+    if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+        t.Fatalf("decoding proposer: %v", err)
+    }
+    if p.ProposerIndex != 1 || p.Pubkey != mockProposerPubkey || p.Graffiti != "Lighthouse/v5.3.0" || !strings.EqualFold(p.FeeRecipient, mockMiner) {
+        t.Errorf("proposer inesperado: %+v", p)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/101", nil))
+    var br domain.BlockReward
+    if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+        t.Fatalf("decoding blockreward: %v", err)
+    }
+    if br.ProposerPubkey != mockProposerPubkey || br.Graffiti != "Lighthouse/v5.3.0" {
+        t.Errorf("esperaba el proposer en la recompensa: %+v", br)
+    }
+}
+
+func TestIntegration_ValidatorSyncDuty(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache, _ := consensus.NewSyncDutiesCache(10, time.Minute)
+    headTracker := newHeadTracker(t, consClient)
+    sdUC := usecase.NewSyncDutiesUseCase(newProposers(consClient, headTracker), consClient, cache, headTracker, nil, 0)
+    vsUC := usecase.NewValidatorSyncDutyUseCase(sdUC, consClient, consClient)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{SyncDuties: sdUC, SyncDuty: vsUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/syncduties/100/validator/1", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var duty domain.ValidatorSyncDuty
+    if err := json.NewDecoder(rec.Body).Decode(&duty); err != nil {
+        t.Fatalf("decoding sync duty: %v", err)
+    }
+    if duty.Member || duty.ValidatorIndex != 1 || duty.Pubkey != mockProposerPubkey || duty.Period != 0 {
+        t.Errorf("resultado inesperado: %+v", duty)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/syncduties/100/validator/0x12", nil))
+    if rec.Code != http.StatusBadRequest {
+        t.Errorf("esperaba 400 para un id inválido, got %d", rec.Code)
+    }
+}
+
+func TestIntegration_NextSyncCommittee(t *testing.T) {
+    mux := http.NewServeMux()
+    mockHead(mux, "101")
+    mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"genesis_time":"1606824023","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}}`)
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/101/sync_committees", func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Query().Get("epoch") != "256" {
+            http.Error(w, "unexpected epoch", http.StatusBadRequest)
+            return
+        }
+        io.WriteString(w, `{"execution_optimistic":false,"finalized":false,"data":{"validators":["3","4"],"validator_aggregates":[["3","4"]]}}`)
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/101/validators", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":[{"index":"3","validator":{"pubkey":"CCC"}},{"index":"4","validator":{"pubkey":"DDD"}}]}`)
+    })
+    mock := httptest.NewServer(mux)
+    defer mock.Close()
+
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache, _ := consensus.NewSyncDutiesCache(10, time.Minute)
+    headTracker := newHeadTracker(t, consClient)
+    sdUC := usecase.NewSyncDutiesUseCase(newProposers(consClient, headTracker), consClient, cache, headTracker, consClient, 0)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{SyncDuties: sdUC})
+    h.Register(r)
+
+    for _, path := range []string{"/syncduties/next", "/syncduties/101?epoch=256"} {
+        rec := httptest.NewRecorder()
+        r.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+        if rec.Code != http.StatusOK {
+            t.Fatalf("%s: status = %d, want 200 (body=%s)", path, rec.Code, rec.Body.String())
+        }
+        var preview domain.SyncCommitteePreview
+        if err := json.NewDecoder(rec.Body).Decode(&preview); err != nil {
+            t.Fatalf("decoding preview: %v", err)
+        }
+        if preview.Period != 1 || preview.StartEpoch != 256 || preview.StartSlot != 8192 {
+            t.Errorf("%s: periodo inesperado: %+v", path, preview)
+        }
+        if want := time.Unix(1606824023+8192*12, 0); !preview.StartTime.Equal(want) {
+            t.Errorf("%s: StartTime = %v, esperaba %v", path, preview.StartTime, want)
+        }
+        if len(preview.Validators) != 2 || preview.Validators[0] != "CCC" {
+            t.Errorf("%s: validadores inesperados: %v", path, preview.Validators)
+        }
+        if len(preview.Subcommittees) != 1 || len(preview.Subcommittees[0].Members) != 2 || preview.Subcommittees[0].Members[1].Pubkey != "DDD" {
+            t.Errorf("%s: subcomités inesperados: %+v", path, preview.Subcommittees)
+        }
+    }
+
+    for path, want := range map[string]int{
+        "/syncduties/101?epoch=512": http.StatusBadRequest,
+        "/syncduties/101?epoch=x":   http.StatusBadRequest,
+    } {
+        rec := httptest.NewRecorder()
+        r.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+        if rec.Code != want {
+            t.Errorf("%s: status = %d, esperaba %d", path, rec.Code, want)
+        }
+    }
+}
+
+func TestIntegration_SyncParticipation(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache, _ := consensus.NewSyncDutiesCache(10, time.Minute)
+    headTracker := newHeadTracker(t, consClient)
+    sdUC := usecase.NewSyncDutiesUseCase(newProposers(consClient, headTracker), consClient, cache, headTracker, nil, 0)
+    spUC := usecase.NewSyncParticipationUseCase(consClient, sdUC)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{SyncDuties: sdUC, Participation: spUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/syncparticipation/100", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var got domain.SyncParticipation
+    if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+        t.Fatalf("decoding participation: %v", err)
+    }
+    // Bits 0x01: position 0 (validator 7) signed, position 1 (validator 8) didn't.
+    if len(got.Participated) != 1 || got.Participated[0].ValidatorIndex != 7 ||
+        len(got.Missed) != 1 || got.Missed[0].ValidatorIndex != 8 || got.ParticipationRate != 0.5 {
+        t.Errorf("participación inesperada: %+v", got)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("POST", "/syncparticipation/100", strings.NewReader(`["8"]`)))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    got = domain.SyncParticipation{}
+    if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+        t.Fatalf("decoding participation: %v", err)
+    }
+    if len(got.Participated) != 0 || len(got.Missed) != 1 || got.ParticipationRate != 0.5 {
+        t.Errorf("filtro inesperado: %+v", got)
+    }
+}
+
+func TestIntegration_SyncDuties_ChunkedValidatorLookup(t *testing.T) {
+    // A full committee of 512 positions held by 300 distinct validators.
+    committee := make([]string, 512)
+    for i := range committee {
+        committee[i] = strconv.Itoa(1000 + i%300)
+    }
+    aggregates := [][]string{committee[:128], committee[128:256], committee[256:384], committee[384:]}
+
+    var mu sync.Mutex
+    var chunks [][]string
+    mux := http.NewServeMux()
+    mockHead(mux, "101")
+    mux.HandleFunc("/eth/v1/beacon/states/100/sync_committees", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(map[string]interface{}{
+            "data": map[string]interface{}{"validators": committee, "validator_aggregates": aggregates},
+        })
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/100/validators", func(w http.ResponseWriter, r *http.Request) {
+        var req struct {
+            IDs []string `json:"ids"`
+        }
+        if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+            w.WriteHeader(http.StatusBadRequest)
+            return
+        }
+        mu.Lock()
+        chunks = append(chunks, req.IDs)
+        mu.Unlock()
+        var data []map[string]interface{}
+        for _, id := range req.IDs {
+            data = append(data, map[string]interface{}{"index": id, "validator": map[string]string{"pubkey": "0xpk" + id}})
+        }
+        json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+    })
+    mux.HandleFunc("/eth/v2/beacon/blocks/100", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, beaconBlockJSON("100", "100"))
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/head/validators/1", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":{"index":"1","validator":{"pubkey":"`+mockProposerPubkey+`"}}}`)
+    })
+    mock := httptest.NewServer(mux)
+    defer mock.Close()
+
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache, _ := consensus.NewSyncDutiesCache(10, time.Minute)
+    headTracker := newHeadTracker(t, consClient)
+    sdUC := usecase.NewSyncDutiesUseCase(newProposers(consClient, headTracker), consClient, cache, headTracker, nil, 0)
+
+    duties, err := sdUC.Execute(context.Background(), 100)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(duties.Validators) != 512 || duties.Validators[0] != "0xpk1000" || duties.Validators[300] != "0xpk1000" || duties.Validators[511] != "0xpk1211" {
+        t.Errorf("pubkeys inesperadas: %v…", duties.Validators[:3])
+    }
+    if m := duties.Subcommittees[3].Members[127]; m.ValidatorIndex != 1211 || m.Pubkey != "0xpk1211" {
+        t.Errorf("miembro inesperado: %+v", m)
+    }
+
+    // 300 distinct ids in chunks of at most 128.
+    var total int
+    for _, c := range chunks {
+        if len(c) > 128 {
+            t.Errorf("esperaba como mucho 128 ids por petición, got %d", len(c))
+        }
+        total += len(c)
+    }
+    if len(chunks) != 3 || total != 300 {
+        t.Errorf("esperaba 3 peticiones con 300 ids únicos, got %d peticiones con %d ids", len(chunks), total)
+    }
+}
+
+func TestIntegration_ValidatorStatus(t *testing.T) {
+    mux := http.NewServeMux()
+    mockHead(mux, "101")
+    var paths []string
+    mux.HandleFunc("/eth/v1/beacon/states/", func(w http.ResponseWriter, r *http.Request) {
+        paths = append(paths, r.URL.Path)
+        if !strings.HasSuffix(r.URL.Path, "/validators/1") && !strings.HasSuffix(r.URL.Path, "/validators/"+mockProposerPubkey) {
+            w.WriteHeader(http.StatusNotFound)
+            io.WriteString(w, `{"code":404,"message":"Validator not found"}`)
+            return
+        }
+        io.WriteString(w, `{"execution_optimistic":false,"finalized":true,"data":{"index":"1","balance":"32012345678",`+
+            `"status":"active_exiting","validator":{"pubkey":"`+mockProposerPubkey+`",`+
+            `"withdrawal_credentials":"0x010000000000000000000000`+strings.ToLower(mockFeeRecipient[2:])+`",`+
+            `"effective_balance":"32000000000","slashed":false,"activation_eligibility_epoch":"0","activation_epoch":"0",`+
+            `"exit_epoch":"350000","withdrawable_epoch":"350256"}}}`)
+    })
+    mock := httptest.NewServer(mux)
+    defer mock.Close()
+
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    vlUC := usecase.NewValidatorStatusUseCase(consClient, newHeadTracker(t, consClient))
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{Validator: vlUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/validator/1?state=finalized", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var got domain.ValidatorStatus
+    if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+        t.Fatalf("decoding validator: %v", err)
+    }
+    if got.Status != domain.ValidatorStatusExiting || got.BeaconStatus != "active_exiting" || got.State != "finalized" || !got.Finalized {
+        t.Errorf("estado inesperado: %+v", got)
+    }
+    if got.BalanceGwei != 32012345678 || got.EffectiveBalanceGwei != 32000000000 || got.ExitEpoch != 350000 || got.WithdrawableEpoch != 350256 {
+        t.Errorf("saldos o épocas inesperados: %+v", got)
+    }
+    if got.Pubkey != mockProposerPubkey || !strings.HasPrefix(got.WithdrawalCredentials, "0x01") {
+        t.Errorf("identidad inesperada: %+v", got)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/validator/"+mockProposerPubkey+"?state=100", nil))
+    if rec.Code != http.StatusOK {
+        t.Errorf("por pubkey: status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    if last := paths[len(paths)-1]; last != "/eth/v1/beacon/states/100/validators/"+mockProposerPubkey {
+        t.Errorf("ruta inesperada: %s", last)
+    }
+
+    for path, want := range map[string]int{
+        "/validator/2":                http.StatusNotFound,
+        "/validator/1?state=102":      http.StatusBadRequest,
+        "/validator/1?state=genesis2": http.StatusBadRequest,
+        "/validator/0x12":             http.StatusBadRequest,
+    } {
+        rec := httptest.NewRecorder()
+        r.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+        if rec.Code != want {
+            t.Errorf("%s: status = %d, esperaba %d", path, rec.Code, want)
+        }
+    }
+}
+
+func mockRelay(slot, blockHash string) *httptest.Server {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/relay/v1/data/bidtraces/proposer_payload_delivered", func(w http.ResponseWriter, r *http.Request) {
+        log.Printf("[MOCK] RELAY GET %s?%s", r.URL.Path, r.URL.RawQuery)
+        w.Header().Set("Content-Type", "application/json")
+        if r.URL.Query().Get("slot") != slot {
+            io.WriteString(w, `[]`)
+            return
+        }
+        io.WriteString(w, `[{"slot":"`+slot+`","block_hash":"`+blockHash+`",`+
+            `"builder_pubkey":"0xaa1488eae4b06a1fff840a2b6db167afc520758dc2c8af0dfb57037954df3431b747e2f900fe8805f05d635e9a29717b",`+
+            `"proposer_fee_recipient":"`+mockFeeRecipient+`","value":"48211000000000001"}]`)
+    })
+    return httptest.NewServer(mux)
+}
+
+func TestIntegration_BlockReward_RelayDeliveries(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+    stubRelay := mockRelay("100", "0xfeebb1c60ceca18290b0f20aa581d34d293e240fcb6ccb5ee283c007dd5814e2")
+    defer stubRelay.Close()
+    downRelay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }))
+    defer downRelay.Close()
+
+    ethHTTP, _ := ethclient.Dial(mock.URL)
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethHTTP, nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    relayClient, err := relay.NewRelayClient([]string{stubRelay.URL, downRelay.URL}, 1, 10*time.Millisecond, 1*time.Second)
+    if err != nil {
+        t.Fatalf("NewRelayClient: %v", err)
+    }
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), relayClient, nil)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/100", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var br domain.BlockReward
+    if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+        t.Fatalf("decoding blockreward: %v", err)
+    }
+    if br.Status != "mev" || len(br.Relays) != 1 {
+        t.Fatalf("esperaba una entrega de relay y estado mev: %+v", br)
+    }
+    d := br.Relays[0]
+    if d.Relay != stubRelay.URL || d.ValueWei != "48211000000000001" || !strings.EqualFold(d.ProposerFeeRecipient, mockFeeRecipient) || d.BuilderPubkey == "" {
+        t.Errorf("entrega de relay inesperada: %+v", d)
+    }
+    // The payment found on chain goes to the relay's fee recipient.
+    if br.ProposerPaymentTx != "0x02" || !strings.EqualFold(br.FeeRecipient, mockFeeRecipient) {
+        t.Errorf("esperaba conservar el pago al proposer: %+v", br)
+    }
+}
+
+func TestRelayClient_AllRelaysDown(t *testing.T) {
+    downRelay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusInternalServerError)
+    }))
+    defer downRelay.Close()
+
+    relayClient, _ := relay.NewRelayClient([]string{downRelay.URL}, 1, 10*time.Millisecond, 1*time.Second)
+    if _, err := relayClient.GetDeliveredPayloads(context.Background(), 100); err == nil {
+        t.Fatal("esperaba error si ningún relay responde")
+    }
+    // Retries of a relay that doesn't answer stay within one timeout.
+    slowRelay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        time.Sleep(300 * time.Millisecond)
+    }))
+    defer slowRelay.Close()
+    relayClient, _ = relay.NewRelayClient([]string{slowRelay.URL}, 3, 10*time.Millisecond, 100*time.Millisecond)
+    start := time.Now()
+    if _, err := relayClient.GetDeliveredPayloads(context.Background(), 100); err == nil {
+        t.Error("esperaba error si el relay no responde a tiempo")
+    }
+    if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+        t.Errorf("la consulta de relays duró %v, esperaba menos de 250ms", elapsed)
+    }
+
+    if _, err := relay.NewRelayClient([]string{"0x4200000000000000000000000000000000000006"}, 1, 0, time.Second); err == nil {
+        t.Error("esperaba error para una dirección en lugar de una URL de relay")
+    }
+}
+
+// blockFeesTransactions are the transactions of block 20971520: a 0.9 ETH
+// transfer to the coinbase, less the 21000 gwei of fees it also earns, and
+// the coinbase's 0.4 ETH payout to the proposer last.
+var blockFeesTransactions = []map[string]interface{}{
+    {"hash": "0x0a", "from": "0x1111111111111111111111111111111111111111", "to": mockMiner, "value": "0xc7d5e21d84fb000"},
+    {"hash": "0x0b", "from": mockMiner, "to": mockFeeRecipient, "value": "0x58d15e176280000"},
+}
+
+func mockBlockFeesNode() *httptest.Server {
+    mux := http.NewServeMux()
+    mockHead(mux, "300")
+
+    mux.HandleFunc("/eth/v2/beacon/blocks/200", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        io.WriteString(w, beaconBlockJSON("200", "20971520"))
+    })
+    mux.HandleFunc("/eth/v2/beacon/blocks/201", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusNotFound)
+    })
+    mockHeaders(mux, map[string]string{
+        "202":    headerJSON("202", "0xb200"),
+        "0xb200": headerJSON("200", "0xb199"),
+    })
+    mux.HandleFunc("/eth/v1/validator/duties/proposer/6", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, `{"data":[{"slot":"201","validator_index":"77"}]}`)
+    })
+
+    type rpcRequest struct {
+        Method string        `json:"method"`
+        ID     int           `json:"id"`
+        Params []interface{} `json:"params"`
+    }
+    reply := func(req rpcRequest) map[string]interface{} {
+        rep := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+        switch req.Method {
+        case "eth_getBalance":
+            // 1 ETH at the parent of block 20971520 and 2.5 ETH after it.
+            if at, _ := req.Params[1].(map[string]interface{}); at["blockHash"] == mockParentHash {
+                rep["result"] = "0xde0b6b3a7640000"
+            } else {
+                rep["result"] = "0x22b1c8c1227a0000"
+            }
+        case "eth_getBlockReceipts":
+            rep["result"] = []map[string]interface{}{
+                {"gasUsed": "0x5208", "effectiveGasPrice": "0x3b9aca00"},
+                {"gasUsed": "0x5208", "effectiveGasPrice": "0x77359400", "blobGasUsed": "0x40000", "blobGasPrice": "0x3"},
+            }
+        case "eth_getBlockByHash":
+            rep["result"] = map[string]interface{}{
+                "difficulty":       "0x0",
+                "extraData":        "0x",
+                "gasLimit":         "0x1c9c380",
+                "gasUsed":          "0xa410",
+                "baseFeePerGas":    "0x3b9aca00",
+                "blobGasUsed":      "0x40000",
+                "excessBlobGas":    "0x0",
+                "hash":             "0xfeebb1c60ceca18290b0f20aa581d34d293e240fcb6ccb5ee283c007dd5814e2",
+                "logsBloom":        "0x" + strings.Repeat("0", 512),
+                "miner":            mockMiner,
+                "mixHash":          "0x81434e7b287e3a3bfb45c5a62f8b84795187242d2b8b059426cc7742097f12a2",
+                "nonce":            "0x0000000000000000",
+                "number":           "0x1400000",
+                "parentHash":       mockParentHash,
+                "receiptsRoot":     "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+                "sha3Uncles":       "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+                "stateRoot":        "0x7a84186c8bce5654cb92a3913c88fe7d2cf4766b4dd2c1759d9f0ff620ec8d53",
+                "timestamp":        "0x66000000",
+                "transactionsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+                "withdrawalsRoot":  "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+                "parentBeaconBlockRoot": "0x0000000000000000000000000000000000000000000000000000000000000000",
+                "withdrawals": []map[string]interface{}{
+                    {"index": "0x1", "validatorIndex": "0x2", "address": mockMiner, "amount": "0x3b9aca00"},
+                    {"index": "0x2", "validatorIndex": "0x3", "address": mockFeeRecipient, "amount": "0x3b9aca00"},
+                },
+            }
+            if len(req.Params) > 1 && req.Params[1] == true {
+                rep["result"].(map[string]interface{})["transactions"] = blockFeesTransactions
+            }
+        case "debug_traceBlockByHash":
+            var traces []map[string]interface{}
+            for _, tx := range blockFeesTransactions {
+                traces = append(traces, map[string]interface{}{"txHash": tx["hash"], "result": map[string]interface{}{
+                    "type": "CALL", "from": tx["from"], "to": tx["to"], "value": tx["value"],
+                }})
+            }
+            rep["result"] = traces
+        default:
+            rep["result"] = nil
+        }
+        return rep
+    }
+
+    mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+        raw, _ := io.ReadAll(r.Body)
+        if len(raw) > 0 && raw[0] == '[' {
+            var batch []rpcRequest
+            if err := json.Unmarshal(raw, &batch); err != nil {
+                w.WriteHeader(http.StatusBadRequest)
+                return
+            }
+            var replies []map[string]interface{}
+            for _, req := range batch {
+                replies = append(replies, reply(req))
+            }
+            json.NewEncoder(w).Encode(replies)
+            return
+        }
+        var req rpcRequest
+        if err := json.Unmarshal(raw, &req); err != nil {
+            w.WriteHeader(http.StatusBadRequest)
+            return
+        }
+        json.NewEncoder(w).Encode(reply(req))
+    })
+    return httptest.NewServer(mux)
+}
+
+func TestIntegration_BlockFees(t *testing.T) {
+    mock := mockBlockFeesNode()
+    defer mock.Close()
+
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethclient.NewClient(rpcHTTP), nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_fees, _ := execution.NewBlockFeesCache(10, time.Minute)
+    bfUC := usecase.NewBlockFeesUseCase(consClient, execClient, cache_fees, newHeadTracker(t, consClient))
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockFees: bfUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/block/200", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var fees domain.BlockFees
+    if err := json.NewDecoder(rec.Body).Decode(&fees); err != nil {
+        t.Fatalf("decoding block fees: %v", err)
+    }
+    if fees.BlockNumber != 20971520 || fees.GasLimit != 30000000 || fees.GasUsed != 42000 {
+        t.Errorf("cabecera inesperada: %+v", fees)
+    }
+    if fees.BaseFeePerGasWei != "1000000000" || fees.BurntFeesWei != "42000000000000" || fees.PriorityFeesWei != "21000000000000" {
+        t.Errorf("comisiones EIP-1559 inesperadas: %+v", fees)
+    }
+    // The blob base fee is the receipts' blobGasPrice, not the minimum that
+    // excess_blob_gas 0 would give.
+    if fees.BlobGasUsed != 262144 || fees.BlobBaseFeeWei != "3" || fees.BlobBurntFeesWei != "786432" {
+        t.Errorf("comisiones de blobs inesperadas: %+v", fees)
+    }
+    if fees.TotalBurntWei != "42000000786432" {
+        t.Errorf("total quemado = %s, esperaba 42000000786432", fees.TotalBurntWei)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/block/201", nil))
+    var missed domain.BlockFees
+    if err := json.NewDecoder(rec.Body).Decode(&missed); err != nil {
+        t.Fatalf("decoding block fees: %v", err)
+    }
+    if rec.Code != http.StatusOK || missed.Status != domain.SlotStatusMissed || missed.ProposerIndex != 77 {
+        t.Errorf("esperaba slot perdido con proposer 77, got %d %+v", rec.Code, missed)
+    }
+}
+
+func TestIntegration_BlockReward_Withdrawals(t *testing.T) {
+    mock := mockBlockFeesNode()
+    defer mock.Close()
+
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethclient.NewClient(rpcHTTP), nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/200?method=auto", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var br domain.BlockReward
+    if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+        t.Fatalf("decoding blockreward: %v", err)
+    }
+    // 1.5 ETH balance delta, of which 1 ETH is a withdrawal to the coinbase.
+    if br.WithdrawalsWei != "1000000000000000000" {
+        t.Errorf("withdrawals = %s, esperaba 1000000000000000000", br.WithdrawalsWei)
+    }
+    if br.CrossCheck == nil || br.CrossCheck.BalanceWei != "500000000000000000" {
+        t.Errorf("esperaba descontar el withdrawal del balance: %+v", br.CrossCheck)
+    }
+}
+
+func TestIntegration_BlockReward_TraceWithdrawals(t *testing.T) {
+    mock := mockBlockFeesNode()
+    defer mock.Close()
+
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethclient.NewClient(rpcHTTP), nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/blockreward/200?method=trace", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var br domain.BlockReward
+    if err := json.NewDecoder(rec.Body).Decode(&br); err != nil {
+        t.Fatalf("decoding blockreward: %v", err)
+    }
+    if br.WithdrawalsWei != "1000000000000000000" {
+        t.Errorf("withdrawals = %s, esperaba 1000000000000000000", br.WithdrawalsWei)
+    }
+    // The payout is the coinbase's own last transaction, not a plain transfer.
+    if br.ProposerPaymentTx != "0x0b" || !strings.EqualFold(br.FeeRecipient, mockFeeRecipient) {
+        t.Errorf("esperaba detectar el pago al proposer: %+v", br)
+    }
+    want := domain.CoinbaseBreakdown{
+        FeesWei:            "21000000000000",
+        DirectTransfersWei: "899979000000000000",
+        InternalCallsWei:   "0",
+        ProposerPaymentWei: "-400000000000000000",
+    }
+    if br.Breakdown == nil || *br.Breakdown != want {
+        t.Fatalf("desglose = %+v, esperaba %+v", br.Breakdown, want)
+    }
+    // The breakdown adds up to the balance delta without the withdrawal.
+    if br.RewardWei != "500000000000000000" {
+        t.Errorf("reward = %s, esperaba 500000000000000000", br.RewardWei)
+    }
+}
+
+func TestIntegration_ProposalReward(t *testing.T) {
+    mock := mockQuickNode()
+    defer mock.Close()
+
+    rpcHTTP, _ := rpc.DialHTTP(mock.URL)
+    execClient, _ := execution.NewExecutionClient(rpcHTTP, ethclient.NewClient(rpcHTTP), nil, 1, 10*time.Millisecond)
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_reward, _ := execution.NewBlockRewardCache(10, time.Minute)
+    cache_cl, _ := consensus.NewConsensusRewardCache(10, time.Minute)
+    brUC := usecase.NewBlockRewardUseCase(consClient, execClient, cache_reward, newHeadTracker(t, consClient), nil, nil)
+    prUC := usecase.NewProposalRewardUseCase(brUC, consClient, cache_cl)
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{BlockReward: brUC, ProposalReward: prUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/proposalreward/101", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var pr domain.ProposalReward
+    if err := json.NewDecoder(rec.Body).Decode(&pr); err != nil {
+        t.Fatalf("decoding proposal reward: %v", err)
+    }
+    if pr.Consensus == nil || pr.Consensus.Total != 40000000 || pr.Consensus.Attestations != 36000000 || pr.Consensus.SyncAggregate != 4000000 {
+        t.Fatalf("recompensa de consenso inesperada: %+v", pr.Consensus)
+    }
+    if pr.ExecutionIncomeWei != "50000000000000000" || pr.TotalWei != "90000000000000000" || pr.TotalEth != "0.09" {
+        t.Errorf("total inesperado: %+v", pr)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/proposalreward/100?method=magic", nil))
+    if rec.Code != http.StatusBadRequest {
+        t.Errorf("esperaba 400 para método inválido, got %d", rec.Code)
+    }
+}
+
+func TestIntegration_AttestationRewards(t *testing.T) {
+    mux := http.NewServeMux()
+    mockHead(mux, "400")
+    var gotBodies []string
+    mux.HandleFunc("/eth/v1/beacon/rewards/attestations/10", func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != http.MethodPost {
+            w.WriteHeader(http.StatusMethodNotAllowed)
+            return
+        }
+        body, _ := io.ReadAll(r.Body)
+        gotBodies = append(gotBodies, string(body))
+        w.Header().Set("Content-Type", "application/json")
+        io.WriteString(w, `{"execution_optimistic":false,"finalized":true,"data":{`+
+            `"ideal_rewards":[{"effective_balance":"32000000000","head":"2856","target":"5511","source":"2966","inclusion_delay":"0","inactivity":"0"}],`+
+            `"total_rewards":[{"validator_index":"1","head":"2856","target":"5511","source":"2966","inactivity":"0"},`+
+            `{"validator_index":"2","head":"0","target":"-5519","source":"-2970","inactivity":"0"}]}}`)
+    })
+    mock := httptest.NewServer(mux)
+    defer mock.Close()
+
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_attestations, _ := consensus.NewAttestationRewardsCache(10, time.Minute)
+    arUC := usecase.NewAttestationRewardsUseCase(consClient, cache_attestations, newHeadTracker(t, consClient))
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{Attestations: arUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/attestationrewards/10?validators=2,1", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var ar domain.AttestationRewards
+    if err := json.NewDecoder(rec.Body).Decode(&ar); err != nil {
+        t.Fatalf("decoding attestation rewards: %v", err)
+    }
+    if !ar.Finalized || len(ar.IdealRewards) != 1 || ar.IdealRewards[0].EffectiveBalance != 32000000000 || ar.IdealRewards[0].Target != 5511 {
+        t.Errorf("recompensas ideales inesperadas: %+v", ar)
+    }
+    if len(ar.TotalRewards) != 2 || ar.TotalRewards[1].ValidatorIndex != 2 || ar.TotalRewards[1].Target != -5519 {
+        t.Errorf("recompensas totales inesperadas: %+v", ar.TotalRewards)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("POST", "/attestationrewards/10", strings.NewReader(`["1","2"]`)))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    if len(gotBodies) != 1 || gotBodies[0] != `["1","2"]` {
+        t.Errorf("esperaba una sola petición al beacon con el conjunto ordenado, got %v", gotBodies)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/attestationrewards/12?validators=1", nil))
+    if rec.Code != http.StatusBadRequest {
+        t.Errorf("esperaba 400 para una época sin completar, got %d", rec.Code)
+    }
+}
+
+func TestIntegration_SyncRewards(t *testing.T) {
+    mux := http.NewServeMux()
+    mockHead(mux, "101")
+    pubkey := "0x" + strings.Repeat("aa", 48)
+    mux.HandleFunc("/eth/v2/beacon/blocks/100", func(w http.ResponseWriter, r *http.Request) {
+        io.WriteString(w, beaconBlockJSON("100", "100"))
+    })
+    mux.HandleFunc("/eth/v1/beacon/rewards/sync_committee/100", func(w http.ResponseWriter, r *http.Request) {
+        body, _ := io.ReadAll(r.Body)
+        if r.Method != http.MethodPost || string(body) != "[]" {
+            w.WriteHeader(http.StatusBadRequest)
+            return
+        }
+        io.WriteString(w, `{"execution_optimistic":false,"finalized":true,"data":[`+
+            `{"validator_index":"7","reward":"21004"},{"validator_index":"8","reward":"-21004"},{"validator_index":"9","reward":"21004"}]}`)
+    })
+    mux.HandleFunc("/eth/v1/beacon/states/head/validators/1", func(w http.ResponseWriter, r *http.Request) {
+        log.Printf("[MOCK] REST GET %s", r.URL.Path)
+        io.WriteString(w, `{"data":{"index":"1","validator":{"pubkey":"`+mockProposerPubkey+`"}}}`)
+    })
+
+    mux.HandleFunc("/eth/v1/beacon/states/100/validators", func(w http.ResponseWriter, r *http.Request) {
+        var req struct {
+            IDs []string `json:"ids"`
+        }
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.IDs) != 1 || req.IDs[0] != pubkey {
+            w.WriteHeader(http.StatusBadRequest)
+            return
+        }
+        io.WriteString(w, `{"data":[{"index":"8","validator":{"pubkey":"`+pubkey+`"}}]}`)
+    })
+    mock := httptest.NewServer(mux)
+    defer mock.Close()
+
+    consClient, _ := consensus.NewConsensusClient(mock.URL, nil, 1, 10*time.Millisecond, 1*time.Second)
+    cache_sync_rewards, _ := consensus.NewSyncCommitteeRewardsCache(10, time.Minute)
+    srUC := usecase.NewSyncRewardsUseCase(consClient, consClient, consClient, cache_sync_rewards, newHeadTracker(t, consClient))
+
+    r := chi.NewRouter()
+    h := handler.NewHandler(handler.UseCases{SyncRewards: srUC})
+    h.Register(r)
+
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/syncrewards/100?validators=9,"+pubkey, nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d, want 200 (body=%s)", rec.Code, rec.Body.String())
+    }
+    var sr domain.SyncCommitteeRewards
+    if err := json.NewDecoder(rec.Body).Decode(&sr); err != nil {
+        t.Fatalf("decoding sync rewards: %v", err)
+    }
+    if !sr.Finalized || len(sr.Rewards) != 2 || sr.Rewards[0].ValidatorIndex != 8 || sr.Rewards[0].Reward != -21004 || sr.Rewards[1].ValidatorIndex != 9 {
+        t.Errorf("recompensas inesperadas: %+v", sr)
+    }
+
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, httptest.NewRequest("GET", "/syncrewards/100?validators=0x12", nil))
+    if rec.Code != http.StatusBadRequest {
+        t.Errorf("esperaba 400 para un id inválido, got %d", rec.Code)
+    }
+}
+
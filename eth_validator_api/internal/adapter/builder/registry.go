@@ -0,0 +1,164 @@
+package builder
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "os"
+    "regexp"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "go.uber.org/zap"
+
+    "eth_validator_api/internal/port"
+)
+
+var _ port.BuilderRegistry = (*Registry)(nil)
+
+type builderEntry struct {
+    Name          string   `json:"name"`
+    ExtraData     []string `json:"extra_data"`
+    FeeRecipients []string `json:"fee_recipients"`
+}
+
+type compiledBuilder struct {
+    name     string
+    patterns []*regexp.Regexp
+}
+
+type registryData struct {
+    builders    []compiledBuilder
+    byRecipient map[string]string
+}
+
+// Registry identifies block builders from the header's extraData and the
+// coinbase address. Its contents come from a JSON file that is re-read when
+// its modification time changes.
+type Registry struct {
+    path         string
+    pollInterval time.Duration
+
+    mu      sync.RWMutex
+    data    registryData
+    modTime time.Time
+}
+
+func NewRegistry(path string, pollInterval time.Duration) (*Registry, error) {
+    r := &Registry{path: path, pollInterval: pollInterval}
+    if path == "" {
+        return r, nil
+    }
+    if _, err := r.reload(); err != nil {
+        return nil, err
+    }
+    return r, nil
+}
+
+// Identify returns the builder's name, or "" if it is unknown. Known
+// fee-recipient addresses take precedence over extraData, which builders
+// don't always fill in.
+func (r *Registry) Identify(extraData []byte, coinbase string) string {
+    r.mu.RLock()
+    data := r.data
+    r.mu.RUnlock()
+
+    if name, ok := data.byRecipient[strings.ToLower(coinbase)]; ok {
+        return name
+    }
+    for _, b := range data.builders {
+        for _, p := range b.patterns {
+            if p.Match(extraData) {
+                return b.name
+            }
+        }
+    }
+    return ""
+}
+
+// Run polls the registry file until ctx is done. A file that fails to load
+// is logged and the previous contents are kept.
+func (r *Registry) Run(ctx context.Context) {
+    if r.path == "" || r.pollInterval <= 0 {
+        return
+    }
+    ticker := time.NewTicker(r.pollInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+        reloaded, err := r.reload()
+        if err != nil {
+            zap.L().Warn("builder registry reload failed", zap.String("path", r.path), zap.Error(err))
+            continue
+        }
+        if reloaded {
+            zap.L().Info("builder registry reloaded", zap.String("path", r.path))
+        }
+    }
+}
+
+func (r *Registry) reload() (bool, error) {
+    info, err := os.Stat(r.path)
+    if err != nil {
+        return false, err
+    }
+    r.mu.RLock()
+    unchanged := info.ModTime().Equal(r.modTime)
+    r.mu.RUnlock()
+    if unchanged {
+        return false, nil
+    }
+
+    raw, err := os.ReadFile(r.path)
+    if err != nil {
+        return false, err
+    }
+    data, err := parseRegistry(raw)
+    if err != nil {
+        return false, err
+    }
+
+    r.mu.Lock()
+    r.data = data
+    r.modTime = info.ModTime()
+    r.mu.Unlock()
+    return true, nil
+}
+
+func parseRegistry(raw []byte) (registryData, error) {
+    var file struct {
+        Builders []builderEntry `json:"builders"`
+    }
+    if err := json.Unmarshal(raw, &file); err != nil {
+        return registryData{}, fmt.Errorf("decoding builder registry: %w", err)
+    }
+
+    data := registryData{byRecipient: make(map[string]string)}
+    for _, entry := range file.Builders {
+        if entry.Name == "" {
+            return registryData{}, fmt.Errorf("builder without a name")
+        }
+        b := compiledBuilder{name: entry.Name}
+        for _, expr := range entry.ExtraData {
+            p, err := regexp.Compile("(?i)" + expr)
+            if err != nil {
+                return registryData{}, fmt.Errorf("builder %s: %w", entry.Name, err)
+            }
+            b.patterns = append(b.patterns, p)
+        }
+        for _, addr := range entry.FeeRecipients {
+            if !common.IsHexAddress(addr) {
+                return registryData{}, fmt.Errorf("builder %s: invalid address %q", entry.Name, addr)
+            }
+            data.byRecipient[strings.ToLower(common.HexToAddress(addr).Hex())] = entry.Name
+        }
+        data.builders = append(data.builders, b)
+    }
+    return data, nil
+}
@@ -0,0 +1,88 @@
+package builder_test
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+
+    "eth_validator_api/internal/adapter/builder"
+)
+
+const titanAddress = "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+
+func writeRegistry(t *testing.T, path, content string, modTime time.Time) {
+    t.Helper()
+    if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+        t.Fatalf("WriteFile: %v", err)
+    }
+    if err := os.Chtimes(path, modTime, modTime); err != nil {
+        t.Fatalf("Chtimes: %v", err)
+    }
+}
+
+func TestRegistry_Identify(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "builders.json")
+    writeRegistry(t, path, `{"builders":[
+        {"name":"Titan","extra_data":["titan"],"fee_recipients":["`+titanAddress+`"]},
+        {"name":"beaverbuild","extra_data":["beaverbuild"]}]}`, time.Now())
+
+    r, err := builder.NewRegistry(path, time.Minute)
+    if err != nil {
+        t.Fatalf("NewRegistry: %v", err)
+    }
+    if name := r.Identify(nil, "0x4838b106fce9647bdf1e7877bf73ce8b0bad5f97"); name != "Titan" {
+        t.Errorf("esperaba Titan por la dirección, got %q", name)
+    }
+    if name := r.Identify([]byte("BeaverBuild.org"), "0x0000000000000000000000000000000000000001"); name != "beaverbuild" {
+        t.Errorf("esperaba beaverbuild por extraData, got %q", name)
+    }
+    if name := r.Identify([]byte("Geth/v1.14"), "0x0000000000000000000000000000000000000001"); name != "" {
+        t.Errorf("esperaba builder desconocido, got %q", name)
+    }
+}
+
+func TestRegistry_ReloadsOnChange(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "builders.json")
+    start := time.Now().Add(-time.Hour)
+    writeRegistry(t, path, `{"builders":[{"name":"Titan","extra_data":["titan"]}]}`, start)
+
+    r, err := builder.NewRegistry(path, 10*time.Millisecond)
+    if err != nil {
+        t.Fatalf("NewRegistry: %v", err)
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    go r.Run(ctx)
+
+    // An invalid file must not wipe the loaded builders.
+    writeRegistry(t, path, `{"builders":[{"name":"Broken","extra_data":["("]}]}`, start.Add(time.Minute))
+    time.Sleep(50 * time.Millisecond)
+    if name := r.Identify([]byte("titan"), ""); name != "Titan" {
+        t.Fatalf("esperaba conservar el registro anterior, got %q", name)
+    }
+
+    writeRegistry(t, path, `{"builders":[{"name":"rsync-builder","extra_data":["rsync"]}]}`, start.Add(2*time.Minute))
+    deadline := time.Now().Add(time.Second)
+    for r.Identify([]byte("rsync-builder.xyz"), "") != "rsync-builder" {
+        if time.Now().After(deadline) {
+            t.Fatal("esperaba que el registro se recargase")
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    if name := r.Identify([]byte("titan"), ""); name != "" {
+        t.Errorf("esperaba que Titan desapareciese tras recargar, got %q", name)
+    }
+}
+
+func TestRegistry_InvalidFile(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "builders.json")
+    writeRegistry(t, path, `{"builders":[{"name":"Titan","fee_recipients":["0x12"]}]}`, time.Now())
+    if _, err := builder.NewRegistry(path, time.Minute); err == nil {
+        t.Error("esperaba error por una dirección inválida")
+    }
+    if _, err := builder.NewRegistry(filepath.Join(t.TempDir(), "missing.json"), time.Minute); err == nil {
+        t.Error("esperaba error si el fichero no existe")
+    }
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+    "time"
+
+    lru "github.com/hashicorp/golang-lru"
+)
+
+// FinalityCache is an LRU cache that keeps finalized entries until they are
+// evicted and expires unfinalized ones after ttl, since a reorg can still
+// change them.
+type FinalityCache[K comparable, V any] struct {
+    lruCache *lru.Cache
+    ttl      time.Duration
+}
+
+type entry[V any] struct {
+    value     V
+    finalized bool
+    ts        time.Time
+}
+
+func NewFinalityCache[K comparable, V any](maxEntries int, ttl time.Duration) (*FinalityCache[K, V], error) {
+    c, err := lru.New(maxEntries)
+    if err != nil {
+        return nil, err
+    }
+    return &FinalityCache[K, V]{
+        lruCache: c,
+        ttl:      ttl,
+    }, nil
+}
+
+func (c *FinalityCache[K, V]) Get(key K) (V, bool) {
+    var zero V
+    raw, ok := c.lruCache.Get(key)
+    if !ok {
+        return zero, false
+    }
+    e := raw.(entry[V])
+    if !e.finalized && time.Since(e.ts) > c.ttl {
+        c.lruCache.Remove(key)
+        return zero, false
+    }
+    return e.value, true
+}
+
+func (c *FinalityCache[K, V]) Add(key K, value V, finalized bool) {
+    c.lruCache.Add(key, entry[V]{
+        value:     value,
+        finalized: finalized,
+        ts:        time.Now(),
+    })
+}
@@ -0,0 +1,39 @@
+package cache_test
+
+import (
+    "testing"
+    "time"
+
+    "eth_validator_api/internal/adapter/cache"
+)
+
+func TestFinalityCache_UnfinalizedExpires(t *testing.T) {
+    c, err := cache.NewFinalityCache[uint64, string](8, 20*time.Millisecond)
+    if err != nil {
+        t.Fatalf("NewFinalityCache: %v", err)
+    }
+    c.Add(1, "unfinalized", false)
+    c.Add(2, "finalized", true)
+
+    if _, ok := c.Get(1); !ok {
+        t.Fatal("esperaba la entrada no finalizada antes del TTL")
+    }
+    time.Sleep(30 * time.Millisecond)
+
+    if _, ok := c.Get(1); ok {
+        t.Error("esperaba que la entrada no finalizada expirase")
+    }
+    if v, ok := c.Get(2); !ok || v != "finalized" {
+        t.Errorf("esperaba conservar la entrada finalizada, got %q %v", v, ok)
+    }
+}
+
+func TestFinalityCache_Eviction(t *testing.T) {
+    c, _ := cache.NewFinalityCache[uint64, string](1, time.Minute)
+    c.Add(1, "a", true)
+    c.Add(2, "b", true)
+
+    if _, ok := c.Get(1); ok {
+        t.Error("esperaba que la entrada más antigua fuese expulsada")
+    }
+}
@@ -1,49 +1,117 @@
 package consensus
 
 import (
+    "strings"
     "time"
 
-    lru "github.com/hashicorp/golang-lru"
+    "eth_validator_api/internal/adapter/cache"
     "eth_validator_api/internal/domain"
 )
 
+// SyncDutiesCache holds sync committees by sync committee period.
 type SyncDutiesCache struct {
-    lruCache *lru.Cache
-    ttl      time.Duration
+    cache *cache.FinalityCache[uint64, domain.SyncDuties]
 }
 
-type cacheEntry struct {
-    duties domain.SyncDuties
-    ts     time.Time
+func NewSyncDutiesCache(maxEntries int, unfinalizedTTL time.Duration) (*SyncDutiesCache, error) {
+    c, err := cache.NewFinalityCache[uint64, domain.SyncDuties](maxEntries, unfinalizedTTL)
+    if err != nil {
+        return nil, err
+    }
+    return &SyncDutiesCache{cache: c}, nil
+}
+
+func (c *SyncDutiesCache) Get(period uint64) (domain.SyncDuties, bool) {
+    return c.cache.Get(period)
+}
+
+func (c *SyncDutiesCache) Add(period uint64, duties domain.SyncDuties) {
+    c.cache.Add(period, duties, duties.Finalized)
+}
+
+type ConsensusRewardCache struct {
+    cache *cache.FinalityCache[uint64, domain.ConsensusBlockReward]
+}
+
+func NewConsensusRewardCache(maxEntries int, unfinalizedTTL time.Duration) (*ConsensusRewardCache, error) {
+    c, err := cache.NewFinalityCache[uint64, domain.ConsensusBlockReward](maxEntries, unfinalizedTTL)
+    if err != nil {
+        return nil, err
+    }
+    return &ConsensusRewardCache{cache: c}, nil
+}
+
+func (c *ConsensusRewardCache) Get(slot uint64) (domain.ConsensusBlockReward, bool) {
+    return c.cache.Get(slot)
+}
+
+func (c *ConsensusRewardCache) Add(slot uint64, reward domain.ConsensusBlockReward) {
+    c.cache.Add(slot, reward, reward.Finalized)
+}
+
+type AttestationRewardsCache struct {
+    cache *cache.FinalityCache[attestationKey, domain.AttestationRewards]
+}
+
+// attestationKey expects the validator list in canonical order; the usecase
+// sorts and dedupes it before lookups.
+type attestationKey struct {
+    epoch      uint64
+    validators string
 }
 
-func NewSyncDutiesCache(maxEntries int, ttl time.Duration) (*SyncDutiesCache, error) {
-    c, err := lru.New(maxEntries)
+func NewAttestationRewardsCache(maxEntries int, unfinalizedTTL time.Duration) (*AttestationRewardsCache, error) {
+    c, err := cache.NewFinalityCache[attestationKey, domain.AttestationRewards](maxEntries, unfinalizedTTL)
     if err != nil {
         return nil, err
     }
-    return &SyncDutiesCache{
-        lruCache: c,
-        ttl:      ttl,
-    }, nil
+    return &AttestationRewardsCache{cache: c}, nil
+}
+
+func (c *AttestationRewardsCache) Get(epoch uint64, validators []string) (domain.AttestationRewards, bool) {
+    return c.cache.Get(attestationKey{epoch: epoch, validators: strings.Join(validators, ",")})
 }
 
-func (c *SyncDutiesCache) Get(slot uint64) (domain.SyncDuties, bool) {
-    raw, ok := c.lruCache.Get(slot)
-    if !ok {
-        return domain.SyncDuties{}, false
+func (c *AttestationRewardsCache) Add(epoch uint64, validators []string, rewards domain.AttestationRewards) {
+    c.cache.Add(attestationKey{epoch: epoch, validators: strings.Join(validators, ",")}, rewards, rewards.Finalized)
+}
+
+type SyncCommitteeRewardsCache struct {
+    cache *cache.FinalityCache[uint64, domain.SyncCommitteeRewards]
+}
+
+func NewSyncCommitteeRewardsCache(maxEntries int, unfinalizedTTL time.Duration) (*SyncCommitteeRewardsCache, error) {
+    c, err := cache.NewFinalityCache[uint64, domain.SyncCommitteeRewards](maxEntries, unfinalizedTTL)
+    if err != nil {
+        return nil, err
     }
-    e := raw.(cacheEntry)
-    if time.Since(e.ts) > c.ttl {
-        c.lruCache.Remove(slot)
-        return domain.SyncDuties{}, false
+    return &SyncCommitteeRewardsCache{cache: c}, nil
+}
+
+func (c *SyncCommitteeRewardsCache) Get(slot uint64) (domain.SyncCommitteeRewards, bool) {
+    return c.cache.Get(slot)
+}
+
+func (c *SyncCommitteeRewardsCache) Add(slot uint64, rewards domain.SyncCommitteeRewards) {
+    c.cache.Add(slot, rewards, rewards.Finalized)
+}
+
+type ProposerCache struct {
+    cache *cache.FinalityCache[uint64, domain.ProposerIdentity]
+}
+
+func NewProposerCache(maxEntries int, unfinalizedTTL time.Duration) (*ProposerCache, error) {
+    c, err := cache.NewFinalityCache[uint64, domain.ProposerIdentity](maxEntries, unfinalizedTTL)
+    if err != nil {
+        return nil, err
     }
-    return e.duties, true
+    return &ProposerCache{cache: c}, nil
+}
+
+func (c *ProposerCache) Get(slot uint64) (domain.ProposerIdentity, bool) {
+    return c.cache.Get(slot)
 }
 
-func (c *SyncDutiesCache) Add(slot uint64, duties domain.SyncDuties) {
-    c.lruCache.Add(slot, cacheEntry{
-        duties: duties,
-        ts:     time.Now(),
-    })
+func (c *ProposerCache) Add(slot uint64, proposer domain.ProposerIdentity) {
+    c.cache.Add(slot, proposer, proposer.Finalized)
 }
@@ -1,17 +1,21 @@
 package consensus
 
 import (
+    "bytes"
     "context"
     "encoding/json"
     "fmt"
     "io"
     "net/http"
     stderrors "errors"         
+    "strconv"
     "strings"
+    "sync"
     "time"
+    "unicode/utf8"
 
+    "github.com/ethereum/go-ethereum/common/hexutil"
     "go.uber.org/zap"
-    "github.com/ethereum/go-ethereum/ethclient"
 
     apierr "eth_validator_api/internal/errors"  
     "eth_validator_api/internal/domain"
@@ -20,106 +24,769 @@ import (
 )
 
 const (
-    syncCommitteesPath = "/eth/v1/beacon/states/%d/sync_committees"
-    validatorsPath     = "/eth/v1/beacon/states/%d/validators?id=%s"
+    syncCommitteesPath      = "/eth/v1/beacon/states/%d/sync_committees?epoch=%d"
+    genesisPath             = "/eth/v1/beacon/genesis"
+    validatorsPath          = "/eth/v1/beacon/states/%s/validators"
+    validatorPath           = "/eth/v1/beacon/states/%s/validators/%s"
+    blockPath               = "/eth/v2/beacon/blocks/%d"
+    headerPath              = "/eth/v1/beacon/headers/%s"
+    finalityCheckpointsPath = "/eth/v1/beacon/states/head/finality_checkpoints"
+    proposerDutiesPath      = "/eth/v1/validator/duties/proposer/%d"
+
+    slotsPerEpoch = 32
+
+    // A run of missed slots longer than this is taken as a gap in the node's
+    // history rather than searched to its end.
+    maxMissedSlots = slotsPerEpoch
+
+    // Validator lookups are split into chunks of this many ids, at most
+    // validatorLookupParallelism of them in flight.
+    validatorLookupChunkSize   = 128
+    validatorLookupParallelism = 4
 )
 
+var (
+    _ port.SyncDutiesClient        = (*ConsensusClient)(nil)
+    _ port.SlotResolver            = (*ConsensusClient)(nil)
+    _ port.ValidatorIndexResolver  = (*ConsensusClient)(nil)
+    _ port.ValidatorPubkeyResolver = (*ConsensusClient)(nil)
+    _ port.GenesisClient           = (*ConsensusClient)(nil)
+    _ port.ValidatorStatusClient   = (*ConsensusClient)(nil)
+)
 
 type ConsensusClient struct {
-    execClient *ethclient.Client
     httpClient *http.Client
     endpoint   string
+    headers    map[string]string
     maxRetries int
     backoff    time.Duration
+
+    genesisMu             sync.Mutex
+    genesisTime           time.Time
+    genesisValidatorsRoot []byte
+
+    registry port.ValidatorRegistry
 }
 
 
-func NewConsensusClient(httpEndpoint string, syncDutiesMaxRetries int, syncDutiesBackoff time.Duration, syncDutiesRequestTimeout time.Duration) (port.SyncDutiesClient, error) {
-   
-    execCli, err := ethclient.Dial(httpEndpoint)
-    if err != nil {
-        return nil, err
-    }
+func NewConsensusClient(beaconEndpoint string, headers map[string]string, maxRetries int, backoff time.Duration, requestTimeout time.Duration) (*ConsensusClient, error) {
 
-	httpCli := &http.Client{Timeout: syncDutiesRequestTimeout}
+	httpCli := &http.Client{Timeout: requestTimeout}
 	
     return &ConsensusClient{
-        execClient: execCli,
         httpClient: httpCli,
-        endpoint:   httpEndpoint,
-        maxRetries: syncDutiesMaxRetries,
-        backoff:    syncDutiesBackoff,
+        endpoint:   strings.TrimRight(beaconEndpoint, "/"),
+        headers:    headers,
+        maxRetries: maxRetries,
+        backoff:    backoff,
     }, nil
 }
 
-func (cc *ConsensusClient) GetSyncDuties(ctx context.Context, slot uint64) (domain.SyncDuties, error) {
-    head, err := cc.execClient.BlockNumber(ctx)
-    if err != nil {
-        zap.L().Error("failed to fetch head slot", zap.Error(err))
-        return domain.SyncDuties{}, err
-    }
-    if slot > head {
-        return domain.SyncDuties{}, apierr.ErrSlotTooFarInFuture
+// SetValidatorRegistry makes validator lookups try the registry before the
+// beacon node. The registry itself is filled through the client, so it can't
+// be passed to the constructor.
+func (cc *ConsensusClient) SetValidatorRegistry(registry port.ValidatorRegistry) {
+    cc.registry = registry
+}
+
+// AltairForkEpoch returns the epoch at which a known network activated sync
+// committees.
+func AltairForkEpoch(network string) (uint64, error) {
+    switch network {
+    case "", "mainnet":
+        return 74240, nil
+    case "sepolia":
+        return 50, nil
+    case "holesky", "hoodi":
+        return 0, nil
     }
+    return 0, fmt.Errorf("unknown network %q", network)
+}
 
-    indices, err := cc.fetchSyncCommittees(ctx, slot)
+// GetSyncDuties returns the sync committee for an epoch, read from the state
+// at the slot. The epoch must fall in the state's current or next period.
+func (cc *ConsensusClient) GetSyncDuties(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error) {
+    indices, aggregates, finality, err := cc.fetchSyncCommittees(ctx, slot, epoch)
     if err != nil {
         return domain.SyncDuties{}, err
     }
     if len(indices) == 0 {
-        return domain.SyncDuties{Validators: []string{}}, nil
+        return domain.SyncDuties{Finality: finality, Validators: []string{}, Subcommittees: []domain.SyncSubcommittee{}}, nil
     }
 
     pubkeys, err := cc.fetchValidatorPubkeys(ctx, slot, indices)
     if err != nil {
         return domain.SyncDuties{}, err
     }
+    byIndex := make(map[string]string, len(indices))
+    for i, idx := range indices {
+        byIndex[idx] = pubkeys[i]
+    }
+    subcommittees, err := syncSubcommittees(aggregates, byIndex)
+    if err != nil {
+        return domain.SyncDuties{}, err
+    }
+
+    return domain.SyncDuties{Finality: finality, Validators: pubkeys, Subcommittees: subcommittees}, nil
+}
 
-    return domain.SyncDuties{Validators: pubkeys}, nil
+// syncSubcommittees pairs every member of the committee's validator
+// aggregates with its pubkey. Aggregate i is served by sync subnet i.
+func syncSubcommittees(aggregates [][]string, pubkeys map[string]string) ([]domain.SyncSubcommittee, error) {
+    subcommittees := make([]domain.SyncSubcommittee, len(aggregates))
+    for i, aggregate := range aggregates {
+        members := make([]domain.SyncCommitteeMember, len(aggregate))
+        for j, idx := range aggregate {
+            index, err := strconv.ParseUint(idx, 10, 64)
+            if err != nil {
+                return nil, fmt.Errorf("invalid validator index %q in sync committee", idx)
+            }
+            members[j] = domain.SyncCommitteeMember{ValidatorIndex: index, Pubkey: pubkeys[idx]}
+        }
+        subcommittees[i] = domain.SyncSubcommittee{Subnet: i, Members: members}
+    }
+    return subcommittees, nil
 }
 
-func (cc *ConsensusClient) fetchSyncCommittees(ctx context.Context, slot uint64) ([]string, error) {
-    url := fmt.Sprintf(cc.endpoint+syncCommitteesPath, slot)
+func (cc *ConsensusClient) ResolveSlot(ctx context.Context, slot uint64) (domain.SlotBlock, error) {
+    url := fmt.Sprintf(cc.endpoint+blockPath, slot)
     body, status, err := cc.doGet(ctx, url)
     if err != nil {
         if stderrors.Is(err, context.DeadlineExceeded) {
-            zap.L().Warn("sync_committees request timed out", zap.Uint64("slot", slot))
-            return nil, apierr.ErrRequestTimeout
+            zap.L().Warn("beacon block request timed out", zap.Uint64("slot", slot))
+            return domain.SlotBlock{}, apierr.ErrRequestTimeout
+        }
+        return domain.SlotBlock{}, err
+    }
+
+    switch status {
+    case http.StatusOK:
+        var out struct {
+            domain.Finality
+            Data struct {
+                Message struct {
+                    ProposerIndex uint64 `json:"proposer_index,string"`
+                    Body          struct {
+                        Graffiti         string `json:"graffiti"`
+                        SyncAggregate    *struct {
+                            SyncCommitteeBits string `json:"sync_committee_bits"`
+                        } `json:"sync_aggregate"`
+                        ExecutionPayload *struct {
+                            BlockNumber  uint64 `json:"block_number,string"`
+                            BlockHash    string `json:"block_hash"`
+                            FeeRecipient string `json:"fee_recipient"`
+                        } `json:"execution_payload"`
+                    } `json:"body"`
+                } `json:"message"`
+            } `json:"data"`
+        }
+        if err := json.Unmarshal(body, &out); err != nil {
+            zap.L().Error("decoding beacon block failed", zap.Error(err))
+            return domain.SlotBlock{}, err
+        }
+        block := domain.SlotBlock{
+            Finality:      out.Finality,
+            Slot:          slot,
+            ProposerIndex: out.Data.Message.ProposerIndex,
+            Graffiti:      decodeGraffiti(out.Data.Message.Body.Graffiti),
+        }
+        if payload := out.Data.Message.Body.ExecutionPayload; payload != nil {
+            block.BlockNumber = payload.BlockNumber
+            block.BlockHash = payload.BlockHash
+            block.FeeRecipient = payload.FeeRecipient
+        }
+        if aggregate := out.Data.Message.Body.SyncAggregate; aggregate != nil {
+            bits, err := hexutil.Decode(aggregate.SyncCommitteeBits)
+            if err != nil {
+                zap.L().Error("decoding sync committee bits failed", zap.Error(err))
+                return domain.SlotBlock{}, err
+            }
+            block.SyncCommitteeBits = bits
+        }
+        return block, nil
+
+    case http.StatusNotFound:
+        return cc.resolveMissingSlot(ctx, slot)
+
+    default:
+        zap.L().Error("unexpected status beacon block", zap.Int("code", status))
+        return domain.SlotBlock{}, fmt.Errorf("unexpected status %d", status)
+    }
+}
+
+// Callers check the slot against the head tracker before resolving it, but a
+// 404 on the block endpoint only means nobody proposed in that slot if the
+// node keeps the blocks around it: a checkpoint-synced or pruned node answers
+// 404 for anything older than its history too.
+func (cc *ConsensusClient) resolveMissingSlot(ctx context.Context, slot uint64) (domain.SlotBlock, error) {
+    stored, err := cc.slotInHistory(ctx, slot)
+    if err != nil {
+        return domain.SlotBlock{}, err
+    }
+    if !stored {
+        zap.L().Warn("slot outside the beacon node's block history", zap.Uint64("slot", slot))
+        return domain.SlotBlock{}, apierr.ErrSlotNotFound
+    }
+    proposer, pubkey, err := cc.fetchProposerDuty(ctx, slot)
+    if err != nil {
+        return domain.SlotBlock{}, err
+    }
+    return domain.SlotBlock{Slot: slot, Missed: true, ProposerIndex: proposer, ProposerPubkey: pubkey}, nil
+}
+
+// slotInHistory reports whether the node has the blocks on both sides of an
+// empty slot: the first block after it and that block's parent, which must
+// come before the slot. At the edge of a node's history the parent is the one
+// missing.
+func (cc *ConsensusClient) slotInHistory(ctx context.Context, slot uint64) (bool, error) {
+    head, found, err := cc.fetchHeader(ctx, "head")
+    if err != nil {
+        return false, err
+    }
+    if !found {
+        return false, fmt.Errorf("head header not found")
+    }
+    if head.Slot < slot {
+        return true, nil
+    }
+
+    next, found := head, head.Slot <= slot+maxMissedSlots
+    for s := slot + 1; s < head.Slot && s <= slot+maxMissedSlots; s++ {
+        h, ok, err := cc.fetchHeader(ctx, strconv.FormatUint(s, 10))
+        if err != nil {
+            return false, err
+        }
+        if ok {
+            next, found = h, true
+            break
+        }
+    }
+    if !found {
+        return false, nil
+    }
+
+    parent, found, err := cc.fetchHeader(ctx, next.ParentRoot)
+    if err != nil || !found {
+        return false, err
+    }
+    return parent.Slot < slot, nil
+}
+
+// decodeGraffiti renders the 32-byte graffiti as text when it is UTF-8 and
+// keeps the hex otherwise.
+func decodeGraffiti(raw string) string {
+    b, err := hexutil.Decode(raw)
+    if err != nil {
+        return raw
+    }
+    b = bytes.TrimRight(b, "\x00")
+    if !utf8.Valid(b) {
+        return raw
+    }
+    return string(b)
+}
+
+// ValidatorPubkey looks a validator's pubkey up by index. The mapping never
+// changes, so the head state is used and no archive node is needed.
+func (cc *ConsensusClient) ValidatorPubkey(ctx context.Context, index uint64) (string, error) {
+    if cc.registry != nil {
+        if pubkey, ok := cc.registry.Pubkey(index); ok {
+            return pubkey, nil
+        }
+    }
+    url := fmt.Sprintf(cc.endpoint+validatorPath, "head", strconv.FormatUint(index, 10))
+    body, status, err := cc.doGet(ctx, url)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("validator request timed out", zap.Uint64("index", index))
+            return "", apierr.ErrRequestTimeout
+        }
+        return "", err
+    }
+
+    switch status {
+    case http.StatusOK:
+        var out struct {
+            Data struct {
+                Validator struct {
+                    Pubkey string `json:"pubkey"`
+                } `json:"validator"`
+            } `json:"data"`
+        }
+        if err := json.Unmarshal(body, &out); err != nil {
+            zap.L().Error("decoding validator failed", zap.Error(err))
+            return "", err
+        }
+        return out.Data.Validator.Pubkey, nil
+
+    case http.StatusNotFound:
+        return "", apierr.ErrValidatorNotFound
+
+    default:
+        zap.L().Error("unexpected status validator", zap.Int("code", status))
+        return "", fmt.Errorf("unexpected status %d", status)
+    }
+}
+
+// GetValidatorStatus reads a validator's record, by index or pubkey, in the
+// state given as "head", "finalized" or a slot.
+func (cc *ConsensusClient) GetValidatorStatus(ctx context.Context, state, id string) (domain.ValidatorStatus, error) {
+    url := fmt.Sprintf(cc.endpoint+validatorPath, state, id)
+    body, status, err := cc.doGet(ctx, url)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("validator request timed out", zap.String("state", state), zap.String("id", id))
+            return domain.ValidatorStatus{}, apierr.ErrRequestTimeout
+        }
+        return domain.ValidatorStatus{}, err
+    }
+
+    switch status {
+    case http.StatusOK:
+        var out struct {
+            domain.Finality
+            Data struct {
+                Index     uint64 `json:"index,string"`
+                Balance   uint64 `json:"balance,string"`
+                Status    string `json:"status"`
+                Validator struct {
+                    Pubkey                     string `json:"pubkey"`
+                    WithdrawalCredentials      string `json:"withdrawal_credentials"`
+                    EffectiveBalance           uint64 `json:"effective_balance,string"`
+                    Slashed                    bool   `json:"slashed"`
+                    ActivationEligibilityEpoch uint64 `json:"activation_eligibility_epoch,string"`
+                    ActivationEpoch            uint64 `json:"activation_epoch,string"`
+                    ExitEpoch                  uint64 `json:"exit_epoch,string"`
+                    WithdrawableEpoch          uint64 `json:"withdrawable_epoch,string"`
+                } `json:"validator"`
+            } `json:"data"`
+        }
+        if err := json.Unmarshal(body, &out); err != nil {
+            zap.L().Error("decoding validator failed", zap.Error(err))
+            return domain.ValidatorStatus{}, err
         }
+        v := out.Data.Validator
+        return domain.ValidatorStatus{
+            Finality:                   out.Finality,
+            State:                      state,
+            Index:                      out.Data.Index,
+            Pubkey:                     v.Pubkey,
+            Status:                     domain.ValidatorStatusFromBeacon(out.Data.Status),
+            BeaconStatus:               out.Data.Status,
+            Slashed:                    v.Slashed,
+            BalanceGwei:                out.Data.Balance,
+            EffectiveBalanceGwei:       v.EffectiveBalance,
+            ActivationEligibilityEpoch: v.ActivationEligibilityEpoch,
+            ActivationEpoch:            v.ActivationEpoch,
+            ExitEpoch:                  v.ExitEpoch,
+            WithdrawableEpoch:          v.WithdrawableEpoch,
+            WithdrawalCredentials:      v.WithdrawalCredentials,
+        }, nil
+
+    case http.StatusNotFound:
+        return domain.ValidatorStatus{}, apierr.ErrValidatorNotFound
+
+    default:
+        zap.L().Error("unexpected status validator", zap.Int("code", status))
+        return domain.ValidatorStatus{}, fmt.Errorf("unexpected status %d", status)
+    }
+}
+
+// GenesisTime returns the chain's genesis time. It never changes, so it is
+// fetched once.
+func (cc *ConsensusClient) GenesisTime(ctx context.Context) (time.Time, error) {
+    cc.genesisMu.Lock()
+    defer cc.genesisMu.Unlock()
+    if err := cc.fetchGenesis(ctx); err != nil {
+        return time.Time{}, err
+    }
+    return cc.genesisTime, nil
+}
+
+// GenesisValidatorsRoot returns the chain's genesis_validators_root, which
+// tells chains apart.
+func (cc *ConsensusClient) GenesisValidatorsRoot(ctx context.Context) ([]byte, error) {
+    cc.genesisMu.Lock()
+    defer cc.genesisMu.Unlock()
+    if err := cc.fetchGenesis(ctx); err != nil {
         return nil, err
     }
+    return cc.genesisValidatorsRoot, nil
+}
+
+// fetchGenesis loads the genesis once. The caller holds genesisMu.
+func (cc *ConsensusClient) fetchGenesis(ctx context.Context) error {
+    if cc.genesisValidatorsRoot != nil {
+        return nil
+    }
+
+    body, status, err := cc.doGet(ctx, cc.endpoint+genesisPath)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("genesis request timed out")
+            return apierr.ErrRequestTimeout
+        }
+        return err
+    }
+    if status != http.StatusOK {
+        zap.L().Error("genesis error", zap.Int("code", status))
+        return fmt.Errorf("genesis returned %d", status)
+    }
+
+    var out struct {
+        Data struct {
+            GenesisTime           int64  `json:"genesis_time,string"`
+            GenesisValidatorsRoot string `json:"genesis_validators_root"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(body, &out); err != nil {
+        zap.L().Error("decoding genesis failed", zap.Error(err))
+        return err
+    }
+    root, err := hexutil.Decode(out.Data.GenesisValidatorsRoot)
+    if err != nil || len(root) != genesisValidatorsRootLength {
+        return fmt.Errorf("invalid genesis_validators_root %q", out.Data.GenesisValidatorsRoot)
+    }
+    cc.genesisTime = time.Unix(out.Data.GenesisTime, 0).UTC()
+    cc.genesisValidatorsRoot = root
+    return nil
+}
+
+func (cc *ConsensusClient) fetchHeadSlot(ctx context.Context) (uint64, error) {
+    head, found, err := cc.fetchHeader(ctx, "head")
+    if err != nil {
+        return 0, err
+    }
+    if !found {
+        zap.L().Error("head header error", zap.Int("code", http.StatusNotFound))
+        return 0, fmt.Errorf("head header returned %d", http.StatusNotFound)
+    }
+    return head.Slot, nil
+}
+
+type blockHeader struct {
+    Slot       uint64
+    ParentRoot string
+}
+
+// fetchHeader reads the header of a block by slot, root or "head". found is
+// false when the node has no such block.
+func (cc *ConsensusClient) fetchHeader(ctx context.Context, blockID string) (blockHeader, bool, error) {
+    body, status, err := cc.doGet(ctx, fmt.Sprintf(cc.endpoint+headerPath, blockID))
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("block header request timed out", zap.String("block", blockID))
+            return blockHeader{}, false, apierr.ErrRequestTimeout
+        }
+        return blockHeader{}, false, err
+    }
+
+    switch status {
+    case http.StatusOK:
+        var out struct {
+            Data struct {
+                Header struct {
+                    Message struct {
+                        Slot       uint64 `json:"slot,string"`
+                        ParentRoot string `json:"parent_root"`
+                    } `json:"message"`
+                } `json:"header"`
+            } `json:"data"`
+        }
+        if err := json.Unmarshal(body, &out); err != nil {
+            zap.L().Error("decoding block header failed", zap.Error(err))
+            return blockHeader{}, false, err
+        }
+        msg := out.Data.Header.Message
+        return blockHeader{Slot: msg.Slot, ParentRoot: msg.ParentRoot}, true, nil
+
+    case http.StatusNotFound:
+        return blockHeader{}, false, nil
+
+    default:
+        zap.L().Error("unexpected status block header", zap.Int("code", status))
+        return blockHeader{}, false, fmt.Errorf("unexpected status %d", status)
+    }
+}
+
+func (cc *ConsensusClient) fetchFinalityCheckpoints(ctx context.Context) (justifiedEpoch, finalizedEpoch uint64, err error) {
+    body, status, err := cc.doGet(ctx, cc.endpoint+finalityCheckpointsPath)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("finality checkpoints request timed out")
+            return 0, 0, apierr.ErrRequestTimeout
+        }
+        return 0, 0, err
+    }
+    if status != http.StatusOK {
+        zap.L().Error("finality checkpoints error", zap.Int("code", status))
+        return 0, 0, fmt.Errorf("finality checkpoints returned %d", status)
+    }
+
+    var out struct {
+        Data struct {
+            CurrentJustified struct {
+                Epoch uint64 `json:"epoch,string"`
+            } `json:"current_justified"`
+            Finalized struct {
+                Epoch uint64 `json:"epoch,string"`
+            } `json:"finalized"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(body, &out); err != nil {
+        zap.L().Error("decoding finality checkpoints failed", zap.Error(err))
+        return 0, 0, err
+    }
+    return out.Data.CurrentJustified.Epoch, out.Data.Finalized.Epoch, nil
+}
+
+func (cc *ConsensusClient) fetchProposerDuty(ctx context.Context, slot uint64) (uint64, string, error) {
+    url := fmt.Sprintf(cc.endpoint+proposerDutiesPath, slot/slotsPerEpoch)
+    body, status, err := cc.doGet(ctx, url)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("proposer duties request timed out", zap.Uint64("slot", slot))
+            return 0, "", apierr.ErrRequestTimeout
+        }
+        return 0, "", err
+    }
+    if status != http.StatusOK {
+        zap.L().Error("proposer duties error", zap.Int("code", status))
+        return 0, "", fmt.Errorf("proposer duties returned %d", status)
+    }
+
+    var out struct {
+        Data []struct {
+            Pubkey         string `json:"pubkey"`
+            Slot           uint64 `json:"slot,string"`
+            ValidatorIndex uint64 `json:"validator_index,string"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(body, &out); err != nil {
+        zap.L().Error("decoding proposer duties failed", zap.Error(err))
+        return 0, "", err
+    }
+    for _, d := range out.Data {
+        if d.Slot == slot {
+            return d.ValidatorIndex, d.Pubkey, nil
+        }
+    }
+    return 0, "", apierr.ErrSlotNotFound
+}
+
+func (cc *ConsensusClient) fetchSyncCommittees(ctx context.Context, slot, epoch uint64) ([]string, [][]string, domain.Finality, error) {
+    url := fmt.Sprintf(cc.endpoint+syncCommitteesPath, slot, epoch)
+    body, status, err := cc.doGet(ctx, url)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("sync_committees request timed out", zap.Uint64("slot", slot))
+            return nil, nil, domain.Finality{}, apierr.ErrRequestTimeout
+        }
+        return nil, nil, domain.Finality{}, err
+    }
 
     switch status {
     case http.StatusOK:
-        var out struct{ Data struct{ Validators []string }} 
+        var out struct {
+            domain.Finality
+            Data struct {
+                Validators          []string   `json:"validators"`
+                ValidatorAggregates [][]string `json:"validator_aggregates"`
+            }
+        }
         if err := json.Unmarshal(body, &out); err != nil {
             zap.L().Error("decoding sync_committees failed", zap.Error(err))
-            return nil, err
+            return nil, nil, domain.Finality{}, err
         }
-        return out.Data.Validators, nil
+        return out.Data.Validators, out.Data.ValidatorAggregates, out.Finality, nil
 
     case http.StatusBadRequest:
         if strings.Contains(string(body), "not activated for Altair") {
-            return nil, nil
+            return nil, nil, domain.Finality{}, nil
         }
-        return nil, apierr.ErrSlotTooFarInFuture
+        return nil, nil, domain.Finality{}, apierr.ErrSlotTooFarInFuture
 
     case http.StatusNotFound:
-        return nil, apierr.ErrSlotNotFound
+        return nil, nil, domain.Finality{}, apierr.ErrSlotNotFound
 
     default:
         zap.L().Error("unexpected status sync_committees", zap.Int("code", status))
-        return nil, fmt.Errorf("unexpected status %d", status)
+        return nil, nil, domain.Finality{}, fmt.Errorf("unexpected status %d", status)
     }
 }
 
 func (cc *ConsensusClient) fetchValidatorPubkeys(ctx context.Context, slot uint64, indices []string) ([]string, error) {
-    idxParam := strings.Join(indices, ",")
-    url := fmt.Sprintf(cc.endpoint+validatorsPath, slot, idxParam)
-    body, status, err := cc.doGet(ctx, url)
+    entries, err := cc.fetchValidators(ctx, slot, indices)
+    if err != nil {
+        return nil, err
+    }
+    byIndex := make(map[string]string, len(entries))
+    for _, e := range entries {
+        byIndex[strconv.FormatUint(e.Index, 10)] = e.Validator.Pubkey
+    }
+
+    pubkeys := make([]string, len(indices))
+    for i, idx := range indices {
+        pubkeys[i] = byIndex[idx]
+    }
+    return pubkeys, nil
+}
+
+// ValidatorIndices maps validator ids to indices. Indices are returned as
+// they are; pubkeys are looked up in the state at the slot.
+func (cc *ConsensusClient) ValidatorIndices(ctx context.Context, slot uint64, ids []string) ([]uint64, error) {
+    indices := make([]uint64, len(ids))
+    var pubkeys []string
+    for i, id := range ids {
+        if strings.HasPrefix(id, "0x") {
+            pubkeys = append(pubkeys, id)
+            continue
+        }
+        idx, err := strconv.ParseUint(id, 10, 64)
+        if err != nil {
+            return nil, apierr.ErrInvalidValidatorID
+        }
+        indices[i] = idx
+    }
+    if len(pubkeys) == 0 {
+        return indices, nil
+    }
+
+    entries, err := cc.fetchValidators(ctx, slot, pubkeys)
+    if err != nil {
+        return nil, err
+    }
+    byPubkey := make(map[string]uint64, len(entries))
+    for _, e := range entries {
+        byPubkey[strings.ToLower(e.Validator.Pubkey)] = e.Index
+    }
+
+    for i, id := range ids {
+        if !strings.HasPrefix(id, "0x") {
+            continue
+        }
+        idx, ok := byPubkey[strings.ToLower(id)]
+        if !ok {
+            return nil, apierr.ErrValidatorNotFound
+        }
+        indices[i] = idx
+    }
+    return indices, nil
+}
+
+type validatorEntry struct {
+    Index     uint64 `json:"index,string"`
+    Validator struct {
+        Pubkey string `json:"pubkey"`
+    } `json:"validator"`
+}
+
+// fetchValidators looks validators up by index or pubkey in the state at the
+// slot. Ids the registry knows are answered locally. The rest go in POST
+// bodies rather than the query string, which overflows URL limits for a full
+// committee, and large sets are split into chunks fetched in parallel.
+// Unknown ids are simply absent from the result.
+func (cc *ConsensusClient) fetchValidators(ctx context.Context, slot uint64, ids []string) ([]validatorEntry, error) {
+    seen := make(map[string]struct{}, len(ids))
+    unique := make([]string, 0, len(ids))
+    var local []validatorEntry
+    for _, id := range ids {
+        if _, ok := seen[id]; ok {
+            continue
+        }
+        seen[id] = struct{}{}
+        if e, ok := cc.lookupRegistry(id); ok {
+            local = append(local, e)
+            continue
+        }
+        unique = append(unique, id)
+    }
+
+    entries, err := cc.fetchValidatorChunks(ctx, strconv.FormatUint(slot, 10), unique)
+    if err != nil {
+        return nil, err
+    }
+    return append(local, entries...), nil
+}
+
+// fetchValidatorChunks splits ids into chunks of validatorLookupChunkSize and
+// fetches them from the state with bounded parallelism. The first failure
+// cancels the chunks still in flight.
+func (cc *ConsensusClient) fetchValidatorChunks(ctx context.Context, state string, ids []string) ([]validatorEntry, error) {
+    var chunks [][]string
+    for start := 0; start < len(ids); start += validatorLookupChunkSize {
+        end := start + validatorLookupChunkSize
+        if end > len(ids) {
+            end = len(ids)
+        }
+        chunks = append(chunks, ids[start:end])
+    }
+
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+    results := make([][]validatorEntry, len(chunks))
+    errs := make([]error, len(chunks))
+    sem := make(chan struct{}, validatorLookupParallelism)
+    var wg sync.WaitGroup
+    for i, chunk := range chunks {
+        wg.Add(1)
+        go func(i int, chunk []string) {
+            defer wg.Done()
+            sem <- struct{}{}
+            defer func() { <-sem }()
+            results[i], errs[i] = cc.fetchValidatorChunk(ctx, state, chunk)
+            if errs[i] != nil {
+                cancel()
+            }
+        }(i, chunk)
+    }
+    wg.Wait()
+
+    var entries []validatorEntry
+    var firstErr error
+    for i := range chunks {
+        if errs[i] == nil {
+            entries = append(entries, results[i]...)
+            continue
+        }
+        // A failed chunk cancels the others; report its error rather than
+        // their cancellations.
+        if firstErr == nil || stderrors.Is(firstErr, context.Canceled) {
+            firstErr = errs[i]
+        }
+    }
+    if firstErr != nil {
+        return nil, firstErr
+    }
+    return entries, nil
+}
+
+// lookupRegistry answers an index or pubkey id from the registry, if any.
+func (cc *ConsensusClient) lookupRegistry(id string) (validatorEntry, bool) {
+    var e validatorEntry
+    if cc.registry == nil {
+        return e, false
+    }
+    if strings.HasPrefix(id, "0x") {
+        index, ok := cc.registry.Index(id)
+        e.Index, e.Validator.Pubkey = index, id
+        return e, ok
+    }
+    index, err := strconv.ParseUint(id, 10, 64)
+    if err != nil {
+        return e, false
+    }
+    pubkey, ok := cc.registry.Pubkey(index)
+    e.Index, e.Validator.Pubkey = index, pubkey
+    return e, ok
+}
+
+func (cc *ConsensusClient) fetchValidatorChunk(ctx context.Context, state string, ids []string) ([]validatorEntry, error) {
+    url := fmt.Sprintf(cc.endpoint+validatorsPath, state)
+    body, status, err := cc.doPost(ctx, url, map[string][]string{"ids": ids})
     if err != nil {
         if stderrors.Is(err, context.DeadlineExceeded) {
-            zap.L().Warn("validators request timed out", zap.Uint64("slot", slot))
+            zap.L().Warn("validators request timed out", zap.String("state", state))
             return nil, apierr.ErrRequestTimeout
         }
         return nil, err
@@ -129,36 +796,45 @@ func (cc *ConsensusClient) fetchValidatorPubkeys(ctx context.Context, slot uint6
         return nil, fmt.Errorf("validators returned %d", status)
     }
 
-    var vr struct{ Data []struct {
-        Index     string `json:"index"`
-        Validator struct{ Pubkey string `json:"pubkey"` } `json:"validator"`
-    } }
+    var vr struct {
+        Data []validatorEntry `json:"data"`
+    }
     if err := json.Unmarshal(body, &vr); err != nil {
         zap.L().Error("decoding validators failed", zap.Error(err))
         return nil, err
     }
-
-    pubkeys := make([]string, len(indices))
-    for i, idx := range indices {
-        for _, e := range vr.Data {
-            if e.Index == idx {
-                pubkeys[i] = e.Validator.Pubkey
-                break
-            }
-        }
-    }
-    return pubkeys, nil
+    return vr.Data, nil
 }
 
 func (cc *ConsensusClient) doGet(ctx context.Context, url string) ([]byte, int, error) {
-    req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+    return cc.do(ctx, http.MethodGet, url, nil)
+}
+
+func (cc *ConsensusClient) doPost(ctx context.Context, url string, payload interface{}) ([]byte, int, error) {
+    data, err := json.Marshal(payload)
     if err != nil {
         return nil, 0, err
     }
+    return cc.do(ctx, http.MethodPost, url, data)
+}
 
+func (cc *ConsensusClient) do(ctx context.Context, method, url string, payload []byte) ([]byte, int, error) {
     var body []byte
     var status int
-    err = retry.Do(ctx, cc.maxRetries, cc.backoff, func() error {
+    err := retry.Do(ctx, cc.maxRetries, cc.backoff, func() error {
+        var reqBody io.Reader
+        if payload != nil {
+            reqBody = bytes.NewReader(payload)
+        }
+        req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+        if err != nil {
+            return err
+        }
+        cc.setHeaders(req)
+        if payload != nil {
+            req.Header.Set("Content-Type", "application/json")
+        }
+
         resp, err := cc.httpClient.Do(req)
         if err != nil {
             return err
@@ -177,3 +853,9 @@ func (cc *ConsensusClient) doGet(ctx context.Context, url string) ([]byte, int,
     }
     return body, status, nil
 }
+
+func (cc *ConsensusClient) setHeaders(req *http.Request) {
+    for k, v := range cc.headers {
+        req.Header.Set(k, v)
+    }
+}
@@ -0,0 +1,209 @@
+package consensus
+
+import (
+    "bufio"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "eth_validator_api/internal/domain"
+    "eth_validator_api/internal/port"
+)
+
+const (
+    eventsPath = "/eth/v1/events?topics=head,finalized_checkpoint"
+
+    // Polling rounds done after the event stream drops before reconnecting.
+    fallbackPolls = 5
+)
+
+var _ port.HeadTracker = (*HeadTracker)(nil)
+
+// HeadTracker keeps the beacon head, justified and finalized slots in memory.
+// It follows the node's SSE event stream and polls the head header while the
+// stream is unavailable.
+type HeadTracker struct {
+    client       *ConsensusClient
+    streamClient *http.Client
+    pollInterval time.Duration
+
+    mu     sync.RWMutex
+    head   domain.ChainHead
+    synced bool
+}
+
+func NewHeadTracker(client *ConsensusClient, pollInterval time.Duration) *HeadTracker {
+    return &HeadTracker{
+        client:       client,
+        streamClient: &http.Client{},
+        pollInterval: pollInterval,
+    }
+}
+
+func (t *HeadTracker) Head() (domain.ChainHead, bool) {
+    t.mu.RLock()
+    defer t.mu.RUnlock()
+    return t.head, t.synced
+}
+
+func (t *HeadTracker) Refresh(ctx context.Context) error {
+    slot, err := t.client.fetchHeadSlot(ctx)
+    if err != nil {
+        return err
+    }
+    justified, finalized, err := t.client.fetchFinalityCheckpoints(ctx)
+    if err != nil {
+        return err
+    }
+
+    t.mu.Lock()
+    t.head = domain.ChainHead{
+        HeadSlot:      slot,
+        JustifiedSlot: justified * slotsPerEpoch,
+        FinalizedSlot: finalized * slotsPerEpoch,
+    }
+    t.synced = true
+    t.mu.Unlock()
+    return nil
+}
+
+func (t *HeadTracker) Run(ctx context.Context) {
+    for {
+        err := t.stream(ctx)
+        if ctx.Err() != nil {
+            return
+        }
+        zap.L().Warn("head event stream interrupted, polling", zap.Error(err))
+        t.poll(ctx, fallbackPolls)
+        if ctx.Err() != nil {
+            return
+        }
+    }
+}
+
+func (t *HeadTracker) poll(ctx context.Context, rounds int) {
+    ticker := time.NewTicker(t.pollInterval)
+    defer ticker.Stop()
+    for i := 0; i < rounds; i++ {
+        if err := t.Refresh(ctx); err != nil {
+            zap.L().Warn("head refresh failed", zap.Error(err))
+        }
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+func (t *HeadTracker) stream(ctx context.Context) error {
+    streamCtx, cancel := context.WithCancel(ctx)
+    defer cancel()
+
+    req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, t.client.endpoint+eventsPath, nil)
+    if err != nil {
+        return err
+    }
+    t.client.setHeaders(req)
+    req.Header.Set("Accept", "text/event-stream")
+
+    // A silent stream is as bad as a closed one: drop it after a few
+    // missed slots so the poller takes over. The watchdog runs from the
+    // request on, so a node that never sends the response headers is
+    // dropped too.
+    stallTimeout := 3 * t.pollInterval
+    watchdog := time.AfterFunc(stallTimeout, cancel)
+    defer watchdog.Stop()
+
+    resp, err := t.streamClient.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("events returned %d", resp.StatusCode)
+    }
+
+    if err := t.Refresh(ctx); err != nil {
+        zap.L().Warn("head refresh failed", zap.Error(err))
+    }
+
+    watchdog.Reset(stallTimeout)
+
+    var event string
+    var data strings.Builder
+    scanner := bufio.NewScanner(resp.Body)
+    for scanner.Scan() {
+        line := scanner.Text()
+        switch {
+        case line == "":
+            if event != "" {
+                watchdog.Reset(stallTimeout)
+                t.handleEvent(ctx, event, []byte(data.String()))
+            }
+            event = ""
+            data.Reset()
+        case strings.HasPrefix(line, "event:"):
+            event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+        case strings.HasPrefix(line, "data:"):
+            data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+        }
+    }
+    if err := scanner.Err(); err != nil {
+        return err
+    }
+    return io.EOF
+}
+
+func (t *HeadTracker) handleEvent(ctx context.Context, event string, data []byte) {
+    switch event {
+    case "head":
+        var ev struct {
+            Slot            uint64 `json:"slot,string"`
+            EpochTransition bool   `json:"epoch_transition"`
+        }
+        if err := json.Unmarshal(data, &ev); err != nil {
+            zap.L().Warn("decoding head event failed", zap.Error(err))
+            return
+        }
+        t.mu.Lock()
+        t.head.HeadSlot = ev.Slot
+        t.synced = true
+        t.mu.Unlock()
+        if ev.EpochTransition {
+            t.refreshCheckpoints(ctx)
+        }
+
+    case "finalized_checkpoint":
+        var ev struct {
+            Epoch uint64 `json:"epoch,string"`
+        }
+        if err := json.Unmarshal(data, &ev); err != nil {
+            zap.L().Warn("decoding finalized_checkpoint event failed", zap.Error(err))
+            return
+        }
+        t.mu.Lock()
+        t.head.FinalizedSlot = ev.Epoch * slotsPerEpoch
+        t.mu.Unlock()
+        t.refreshCheckpoints(ctx)
+    }
+}
+
+func (t *HeadTracker) refreshCheckpoints(ctx context.Context) {
+    justified, finalized, err := t.client.fetchFinalityCheckpoints(ctx)
+    if err != nil {
+        zap.L().Warn("finality checkpoints refresh failed", zap.Error(err))
+        return
+    }
+    t.mu.Lock()
+    t.head.JustifiedSlot = justified * slotsPerEpoch
+    t.head.FinalizedSlot = finalized * slotsPerEpoch
+    t.mu.Unlock()
+}
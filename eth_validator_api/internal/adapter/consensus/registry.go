@@ -0,0 +1,268 @@
+package consensus
+
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "strconv"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "go.uber.org/zap"
+
+    "eth_validator_api/internal/port"
+)
+
+const (
+    pubkeyLength                = 48
+    genesisValidatorsRootLength = 32
+
+    // The registry follows the finalized state, so an index it has learned
+    // can't be reorged away.
+    registryState = "finalized"
+    // Validators are requested by index in pages of this size, both for the
+    // initial load and for new deposits. Each page goes through the same
+    // chunked lookup as fetchValidators, so no single request carries more
+    // than validatorLookupChunkSize ids and the consensus client timeout
+    // covers one chunk rather than the whole page.
+    registryPageSize = 8 * validatorLookupChunkSize
+)
+
+var _ port.ValidatorRegistry = (*ValidatorRegistry)(nil)
+
+// ValidatorRegistry keeps a local copy of the index↔pubkey mapping, which
+// never changes once a validator is assigned an index. It is loaded from the
+// beacon node once, persisted to disk and then extended with new deposits.
+//
+// The file starts with the chain's 32-byte genesis_validators_root, followed
+// by the raw 48-byte pubkeys in index order, so it only grows by appending
+// and the index of a pubkey is its offset. A file written for another chain
+// is dropped and the registry is downloaded again.
+type ValidatorRegistry struct {
+    client       *ConsensusClient
+    path         string
+    pollInterval time.Duration
+
+    // Set by the first Sync, once the file is checked against the node's
+    // chain. Only Sync touches it.
+    loaded bool
+
+    mu       sync.RWMutex
+    pubkeys  [][pubkeyLength]byte
+    byPubkey map[[pubkeyLength]byte]uint64
+}
+
+// NewValidatorRegistry doesn't read the file yet: which chain it belongs to
+// is only known once the node answers, so it is loaded by the first Sync.
+func NewValidatorRegistry(client *ConsensusClient, path string, pollInterval time.Duration) (*ValidatorRegistry, error) {
+    r := &ValidatorRegistry{
+        client:       client,
+        path:         path,
+        pollInterval: pollInterval,
+        byPubkey:     make(map[[pubkeyLength]byte]uint64),
+    }
+    if path != "" {
+        if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+            return nil, err
+        }
+    }
+    return r, nil
+}
+
+func (r *ValidatorRegistry) Pubkey(index uint64) (string, bool) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    if index >= uint64(len(r.pubkeys)) {
+        return "", false
+    }
+    return hexutil.Encode(r.pubkeys[index][:]), true
+}
+
+func (r *ValidatorRegistry) Index(pubkey string) (uint64, bool) {
+    b, err := hexutil.Decode(pubkey)
+    if err != nil || len(b) != pubkeyLength {
+        return 0, false
+    }
+    var key [pubkeyLength]byte
+    copy(key[:], b)
+
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    index, ok := r.byPubkey[key]
+    return index, ok
+}
+
+// Len is the number of validators the registry knows.
+func (r *ValidatorRegistry) Len() int {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    return len(r.pubkeys)
+}
+
+// Run catches up with the finalized state and then follows new deposits
+// until ctx is done. Lookups fall back to the beacon node meanwhile.
+func (r *ValidatorRegistry) Run(ctx context.Context) {
+    if err := r.Sync(ctx); err != nil {
+        zap.L().Warn("validator registry sync failed", zap.Error(err))
+    }
+    if r.pollInterval <= 0 {
+        return
+    }
+    ticker := time.NewTicker(r.pollInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+        if err := r.Sync(ctx); err != nil {
+            zap.L().Warn("validator registry sync failed", zap.Error(err))
+        }
+    }
+}
+
+// Sync adds the validators of the finalized state the registry doesn't know
+// yet, one page of indices at a time, until a page comes back short. The
+// first call loads the file.
+func (r *ValidatorRegistry) Sync(ctx context.Context) error {
+    if !r.loaded {
+        if err := r.load(ctx); err != nil {
+            return err
+        }
+        r.loaded = true
+    }
+    start := r.Len()
+    for {
+        next := uint64(r.Len())
+        ids := make([]string, registryPageSize)
+        for i := range ids {
+            ids[i] = strconv.FormatUint(next+uint64(i), 10)
+        }
+        entries, err := r.client.fetchValidatorChunks(ctx, registryState, ids)
+        if err != nil {
+            return err
+        }
+        page, err := contiguousPubkeys(entries, next)
+        if err != nil {
+            return err
+        }
+        if err := r.append(page); err != nil {
+            return err
+        }
+        if len(page) < registryPageSize {
+            break
+        }
+    }
+    if added := r.Len() - start; added > 0 {
+        zap.L().Info("validator registry synced", zap.Int("added", added), zap.Int("validators", r.Len()))
+    }
+    return nil
+}
+
+// contiguousPubkeys returns the pubkeys of the entries whose indices follow
+// on from next without a gap.
+func contiguousPubkeys(entries []validatorEntry, next uint64) ([][pubkeyLength]byte, error) {
+    sort.Slice(entries, func(i, j int) bool { return entries[i].Index < entries[j].Index })
+    var pubkeys [][pubkeyLength]byte
+    for _, e := range entries {
+        if e.Index != next+uint64(len(pubkeys)) {
+            break
+        }
+        b, err := hexutil.Decode(e.Validator.Pubkey)
+        if err != nil || len(b) != pubkeyLength {
+            return nil, fmt.Errorf("validator %d: invalid pubkey %q", e.Index, e.Validator.Pubkey)
+        }
+        var key [pubkeyLength]byte
+        copy(key[:], b)
+        pubkeys = append(pubkeys, key)
+    }
+    return pubkeys, nil
+}
+
+// append persists the pubkeys before publishing them, so the file never
+// lags behind what lookups have answered.
+func (r *ValidatorRegistry) append(pubkeys [][pubkeyLength]byte) error {
+    if len(pubkeys) == 0 {
+        return nil
+    }
+    if r.path != "" {
+        if err := r.persist(pubkeys); err != nil {
+            return err
+        }
+    }
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for _, key := range pubkeys {
+        r.byPubkey[key] = uint64(len(r.pubkeys))
+        r.pubkeys = append(r.pubkeys, key)
+    }
+    return nil
+}
+
+func (r *ValidatorRegistry) persist(pubkeys [][pubkeyLength]byte) error {
+    f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+    if err != nil {
+        return err
+    }
+    buf := make([]byte, 0, len(pubkeys)*pubkeyLength)
+    for _, key := range pubkeys {
+        buf = append(buf, key[:]...)
+    }
+    if _, err := f.Write(buf); err != nil {
+        f.Close()
+        return err
+    }
+    return f.Close()
+}
+
+// load reads the persisted registry if it was written for the node's chain,
+// and starts a new file otherwise. A partial record left by an interrupted
+// write is cut off so later appends stay aligned.
+func (r *ValidatorRegistry) load(ctx context.Context) error {
+    if r.path == "" {
+        return nil
+    }
+    root, err := r.client.GenesisValidatorsRoot(ctx)
+    if err != nil {
+        return err
+    }
+
+    raw, err := os.ReadFile(r.path)
+    if err != nil && !os.IsNotExist(err) {
+        return err
+    }
+    if len(raw) < genesisValidatorsRootLength || !bytes.Equal(raw[:genesisValidatorsRootLength], root) {
+        if len(raw) > 0 {
+            zap.L().Warn("validator registry belongs to another chain, dropping it", zap.String("path", r.path))
+        }
+        return os.WriteFile(r.path, root, 0o644)
+    }
+
+    raw = raw[genesisValidatorsRootLength:]
+    if tail := len(raw) % pubkeyLength; tail != 0 {
+        zap.L().Warn("truncating partial validator registry record", zap.String("path", r.path), zap.Int("bytes", tail))
+        raw = raw[:len(raw)-tail]
+        if err := os.Truncate(r.path, int64(genesisValidatorsRootLength+len(raw))); err != nil {
+            return err
+        }
+    }
+
+    pubkeys := make([][pubkeyLength]byte, len(raw)/pubkeyLength)
+    for i := range pubkeys {
+        copy(pubkeys[i][:], raw[i*pubkeyLength:])
+    }
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for _, key := range pubkeys {
+        r.byPubkey[key] = uint64(len(r.pubkeys))
+        r.pubkeys = append(r.pubkeys, key)
+    }
+    return nil
+}
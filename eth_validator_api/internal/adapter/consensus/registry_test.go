@@ -0,0 +1,271 @@
+package consensus_test
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "eth_validator_api/internal/adapter/consensus"
+)
+
+func testPubkey(index int) string {
+    return fmt.Sprintf("0x%s%04x", strings.Repeat("ab", 46), index)
+}
+
+const (
+    mainnetRoot = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+    sepoliaRoot = "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078"
+)
+
+// validatorNode serves POST /states/{state}/validators for the first count
+// validators of the chain with the given genesis validators root, and
+// records which states were queried and the largest request.
+type validatorNode struct {
+    mu     sync.Mutex
+    root   string
+    count  int
+    states []string
+    maxIDs int
+}
+
+func (n *validatorNode) setCount(count int) {
+    n.mu.Lock()
+    n.count = count
+    n.mu.Unlock()
+}
+
+func (n *validatorNode) handler(w http.ResponseWriter, r *http.Request) {
+    if r.URL.Path == "/eth/v1/beacon/genesis" {
+        n.mu.Lock()
+        root := n.root
+        n.mu.Unlock()
+        fmt.Fprintf(w, `{"data":{"genesis_time":"1606824023","genesis_validators_root":"%s"}}`, root)
+        return
+    }
+    state := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/states/"), "/validators")
+    var req struct {
+        IDs []string `json:"ids"`
+    }
+    if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+        w.WriteHeader(http.StatusBadRequest)
+        return
+    }
+
+    n.mu.Lock()
+    n.states = append(n.states, state)
+    if len(req.IDs) > n.maxIDs {
+        n.maxIDs = len(req.IDs)
+    }
+    count := n.count
+    n.mu.Unlock()
+
+    data := []map[string]interface{}{}
+    for _, id := range req.IDs {
+        index, err := strconv.Atoi(id)
+        if err != nil {
+            index = count
+            for i := 0; i < count; i++ {
+                if testPubkey(i) == id {
+                    index = i
+                }
+            }
+        }
+        if index >= count {
+            continue
+        }
+        data = append(data, map[string]interface{}{
+            "index":     strconv.Itoa(index),
+            "validator": map[string]string{"pubkey": testPubkey(index)},
+        })
+    }
+    json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func newValidatorNode(t *testing.T, count int) (*validatorNode, *consensus.ConsensusClient) {
+    t.Helper()
+    node := &validatorNode{root: mainnetRoot, count: count}
+    srv := httptest.NewServer(http.HandlerFunc(node.handler))
+    t.Cleanup(srv.Close)
+    client, err := consensus.NewConsensusClient(srv.URL, nil, 1, 10*time.Millisecond, time.Second)
+    if err != nil {
+        t.Fatalf("NewConsensusClient: %v", err)
+    }
+    return node, client
+}
+
+func TestValidatorRegistry_SyncAndPersist(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "data", "validators.bin")
+    node, client := newValidatorNode(t, 3)
+
+    r, err := consensus.NewValidatorRegistry(client, path, time.Minute)
+    if err != nil {
+        t.Fatalf("NewValidatorRegistry: %v", err)
+    }
+    if err := r.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    if r.Len() != 3 {
+        t.Fatalf("esperaba 3 validadores, got %d", r.Len())
+    }
+    if pubkey, ok := r.Pubkey(2); !ok || pubkey != testPubkey(2) {
+        t.Errorf("Pubkey(2) = %q, %v", pubkey, ok)
+    }
+    if index, ok := r.Index("0x" + strings.ToUpper(testPubkey(1)[2:])); !ok || index != 1 {
+        t.Errorf("Index = %d, %v, esperaba 1", index, ok)
+    }
+    if _, ok := r.Pubkey(3); ok {
+        t.Error("no esperaba conocer el validador 3")
+    }
+    if node.states[0] != "finalized" {
+        t.Errorf("esperaba seguir el estado finalizado, got %v", node.states)
+    }
+
+    // New deposits are appended to the file.
+    node.setCount(5)
+    if err := r.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    info, err := os.Stat(path)
+    if err != nil {
+        t.Fatalf("Stat: %v", err)
+    }
+    if r.Len() != 5 || info.Size() != 32+5*48 {
+        t.Errorf("esperaba 5 validadores en memoria y en disco, got %d y %d bytes", r.Len(), info.Size())
+    }
+
+    // A restart loads the file and only asks the node for new deposits.
+    reloaded, err := consensus.NewValidatorRegistry(client, path, time.Minute)
+    if err != nil {
+        t.Fatalf("NewValidatorRegistry: %v", err)
+    }
+    node.states = nil
+    if err := reloaded.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    // One page of new deposits, in chunks of 128.
+    if len(node.states) != 8 {
+        t.Errorf("esperaba una sola página de depósitos nuevos, got %d consultas", len(node.states))
+    }
+    if pubkey, ok := reloaded.Pubkey(4); !ok || pubkey != testPubkey(4) {
+        t.Errorf("Pubkey(4) tras recargar = %q, %v", pubkey, ok)
+    }
+    if index, ok := reloaded.Index(testPubkey(3)); !ok || index != 3 {
+        t.Errorf("Index tras recargar = %d, %v", index, ok)
+    }
+}
+
+func TestValidatorRegistry_SyncsInChunks(t *testing.T) {
+    node, client := newValidatorNode(t, 1500)
+    r, _ := consensus.NewValidatorRegistry(client, "", time.Minute)
+    if err := r.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    if r.Len() != 1500 {
+        t.Fatalf("esperaba 1500 validadores, got %d", r.Len())
+    }
+    if pubkey, ok := r.Pubkey(1499); !ok || pubkey != testPubkey(1499) {
+        t.Errorf("Pubkey(1499) = %q, %v", pubkey, ok)
+    }
+    if node.maxIDs > 128 {
+        t.Errorf("esperaba como mucho 128 ids por petición, got %d", node.maxIDs)
+    }
+}
+
+func TestValidatorRegistry_TruncatesPartialRecord(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "validators.bin")
+    node, client := newValidatorNode(t, 2)
+
+    r, _ := consensus.NewValidatorRegistry(client, path, time.Minute)
+    if err := r.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+    f.Write([]byte{1, 2, 3})
+    f.Close()
+
+    reloaded, err := consensus.NewValidatorRegistry(client, path, time.Minute)
+    if err != nil {
+        t.Fatalf("NewValidatorRegistry: %v", err)
+    }
+    node.setCount(3)
+    if err := reloaded.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    if pubkey, ok := reloaded.Pubkey(2); !ok || pubkey != testPubkey(2) {
+        t.Errorf("esperaba el validador 2 tras descartar el registro parcial, got %q, %v", pubkey, ok)
+    }
+    if info, _ := os.Stat(path); info.Size() != 32+3*48 {
+        t.Errorf("esperaba %d bytes, got %d", 32+3*48, info.Size())
+    }
+}
+
+func TestValidatorRegistry_DropsOtherChain(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "validators.bin")
+    _, client := newValidatorNode(t, 3)
+
+    r, _ := consensus.NewValidatorRegistry(client, path, time.Minute)
+    if err := r.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+
+    // The same file, now used against a node of another chain.
+    other, otherClient := newValidatorNode(t, 2)
+    other.root = sepoliaRoot
+    reloaded, _ := consensus.NewValidatorRegistry(otherClient, path, time.Minute)
+    if _, ok := reloaded.Pubkey(0); ok {
+        t.Error("no esperaba responder antes de comprobar la cadena")
+    }
+    if err := reloaded.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    if reloaded.Len() != 2 {
+        t.Errorf("esperaba descartar el registro de la otra cadena, got %d validadores", reloaded.Len())
+    }
+    if info, _ := os.Stat(path); info.Size() != 32+2*48 {
+        t.Errorf("esperaba %d bytes, got %d", 32+2*48, info.Size())
+    }
+}
+
+func TestConsensusClient_UsesValidatorRegistry(t *testing.T) {
+    node, client := newValidatorNode(t, 4)
+    r, _ := consensus.NewValidatorRegistry(client, "", time.Minute)
+    if err := r.Sync(context.Background()); err != nil {
+        t.Fatalf("Sync: %v", err)
+    }
+    client.SetValidatorRegistry(r)
+    node.setCount(6)
+    node.states = nil
+
+    indices, err := client.ValidatorIndices(context.Background(), 100, []string{testPubkey(1), "3"})
+    if err != nil {
+        t.Fatalf("ValidatorIndices: %v", err)
+    }
+    if indices[0] != 1 || indices[1] != 3 {
+        t.Errorf("índices inesperados: %v", indices)
+    }
+    pubkey, err := client.ValidatorPubkey(context.Background(), 2)
+    if err != nil || pubkey != testPubkey(2) {
+        t.Errorf("ValidatorPubkey(2) = %q, %v", pubkey, err)
+    }
+    if len(node.states) != 0 {
+        t.Errorf("esperaba responder desde el registro, got consultas %v", node.states)
+    }
+
+    // Validators the registry doesn't know yet still reach the node.
+    indices, err = client.ValidatorIndices(context.Background(), 100, []string{testPubkey(5)})
+    if err != nil || indices[0] != 5 {
+        t.Errorf("ValidatorIndices = %v, %v", indices, err)
+    }
+    if len(node.states) != 1 || node.states[0] != "100" {
+        t.Errorf("esperaba una consulta al estado 100, got %v", node.states)
+    }
+}
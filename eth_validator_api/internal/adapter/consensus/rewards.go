@@ -0,0 +1,193 @@
+package consensus
+
+import (
+    "context"
+    "encoding/json"
+    stderrors "errors"
+    "fmt"
+    "net/http"
+
+    "go.uber.org/zap"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+const (
+    blockRewardsPath       = "/eth/v1/beacon/rewards/blocks/%d"
+    attestationRewardsPath = "/eth/v1/beacon/rewards/attestations/%d"
+    syncRewardsPath        = "/eth/v1/beacon/rewards/sync_committee/%d"
+)
+
+var (
+    _ port.ConsensusRewardsClient     = (*ConsensusClient)(nil)
+    _ port.AttestationRewardsClient   = (*ConsensusClient)(nil)
+    _ port.SyncCommitteeRewardsClient = (*ConsensusClient)(nil)
+)
+
+func (cc *ConsensusClient) GetBlockRewards(ctx context.Context, slot uint64) (domain.ConsensusBlockReward, error) {
+    url := fmt.Sprintf(cc.endpoint+blockRewardsPath, slot)
+    body, status, err := cc.doGet(ctx, url)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("block rewards request timed out", zap.Uint64("slot", slot))
+            return domain.ConsensusBlockReward{}, apierr.ErrRequestTimeout
+        }
+        return domain.ConsensusBlockReward{}, err
+    }
+
+    switch status {
+    case http.StatusOK:
+    case http.StatusNotFound:
+        return domain.ConsensusBlockReward{}, apierr.ErrSlotNotFound
+    default:
+        zap.L().Error("block rewards error", zap.Int("code", status))
+        return domain.ConsensusBlockReward{}, fmt.Errorf("block rewards returned %d", status)
+    }
+
+    var out struct {
+        domain.Finality
+        Data struct {
+            ProposerIndex     uint64 `json:"proposer_index,string"`
+            Total             uint64 `json:"total,string"`
+            Attestations      uint64 `json:"attestations,string"`
+            SyncAggregate     uint64 `json:"sync_aggregate,string"`
+            ProposerSlashings uint64 `json:"proposer_slashings,string"`
+            AttesterSlashings uint64 `json:"attester_slashings,string"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(body, &out); err != nil {
+        zap.L().Error("decoding block rewards failed", zap.Error(err))
+        return domain.ConsensusBlockReward{}, err
+    }
+    return domain.ConsensusBlockReward{
+        Finality:          out.Finality,
+        Slot:              slot,
+        ProposerIndex:     out.Data.ProposerIndex,
+        Total:             out.Data.Total,
+        Attestations:      out.Data.Attestations,
+        SyncAggregate:     out.Data.SyncAggregate,
+        ProposerSlashings: out.Data.ProposerSlashings,
+        AttesterSlashings: out.Data.AttesterSlashings,
+    }, nil
+}
+
+func (cc *ConsensusClient) GetAttestationRewards(ctx context.Context, epoch uint64, validators []string) (domain.AttestationRewards, error) {
+    url := fmt.Sprintf(cc.endpoint+attestationRewardsPath, epoch)
+    body, status, err := cc.doPost(ctx, url, validators)
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("attestation rewards request timed out", zap.Uint64("epoch", epoch))
+            return domain.AttestationRewards{}, apierr.ErrRequestTimeout
+        }
+        return domain.AttestationRewards{}, err
+    }
+
+    switch status {
+    case http.StatusOK:
+    case http.StatusNotFound, http.StatusBadRequest:
+        zap.L().Warn("attestation rewards unavailable", zap.Uint64("epoch", epoch), zap.ByteString("body", body))
+        return domain.AttestationRewards{}, apierr.ErrRewardsUnavailable
+    default:
+        zap.L().Error("attestation rewards error", zap.Int("code", status))
+        return domain.AttestationRewards{}, fmt.Errorf("attestation rewards returned %d", status)
+    }
+
+    var out struct {
+        domain.Finality
+        Data struct {
+            IdealRewards []struct {
+                EffectiveBalance uint64 `json:"effective_balance,string"`
+                Head             int64  `json:"head,string"`
+                Target           int64  `json:"target,string"`
+                Source           int64  `json:"source,string"`
+                Inactivity       int64  `json:"inactivity,string"`
+            } `json:"ideal_rewards"`
+            TotalRewards []struct {
+                ValidatorIndex uint64 `json:"validator_index,string"`
+                Head           int64  `json:"head,string"`
+                Target         int64  `json:"target,string"`
+                Source         int64  `json:"source,string"`
+                Inactivity     int64  `json:"inactivity,string"`
+            } `json:"total_rewards"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(body, &out); err != nil {
+        zap.L().Error("decoding attestation rewards failed", zap.Error(err))
+        return domain.AttestationRewards{}, err
+    }
+
+    rewards := domain.AttestationRewards{
+        Finality:     out.Finality,
+        Epoch:        epoch,
+        IdealRewards: make([]domain.IdealAttestationReward, 0, len(out.Data.IdealRewards)),
+        TotalRewards: make([]domain.AttestationReward, 0, len(out.Data.TotalRewards)),
+    }
+    for _, r := range out.Data.IdealRewards {
+        rewards.IdealRewards = append(rewards.IdealRewards, domain.IdealAttestationReward{
+            EffectiveBalance: r.EffectiveBalance,
+            Head:             r.Head,
+            Target:           r.Target,
+            Source:           r.Source,
+            Inactivity:       r.Inactivity,
+        })
+    }
+    for _, r := range out.Data.TotalRewards {
+        rewards.TotalRewards = append(rewards.TotalRewards, domain.AttestationReward{
+            ValidatorIndex: r.ValidatorIndex,
+            Head:           r.Head,
+            Target:         r.Target,
+            Source:         r.Source,
+            Inactivity:     r.Inactivity,
+        })
+    }
+    return rewards, nil
+}
+
+// GetSyncCommitteeRewards returns the rewards of every committee member; an
+// empty validator list asks the node for the whole committee.
+func (cc *ConsensusClient) GetSyncCommitteeRewards(ctx context.Context, slot uint64) (domain.SyncCommitteeRewards, error) {
+    url := fmt.Sprintf(cc.endpoint+syncRewardsPath, slot)
+    body, status, err := cc.doPost(ctx, url, []string{})
+    if err != nil {
+        if stderrors.Is(err, context.DeadlineExceeded) {
+            zap.L().Warn("sync committee rewards request timed out", zap.Uint64("slot", slot))
+            return domain.SyncCommitteeRewards{}, apierr.ErrRequestTimeout
+        }
+        return domain.SyncCommitteeRewards{}, err
+    }
+
+    switch status {
+    case http.StatusOK:
+    case http.StatusNotFound, http.StatusBadRequest:
+        zap.L().Warn("sync committee rewards unavailable", zap.Uint64("slot", slot), zap.ByteString("body", body))
+        return domain.SyncCommitteeRewards{}, apierr.ErrRewardsUnavailable
+    default:
+        zap.L().Error("sync committee rewards error", zap.Int("code", status))
+        return domain.SyncCommitteeRewards{}, fmt.Errorf("sync committee rewards returned %d", status)
+    }
+
+    var out struct {
+        domain.Finality
+        Data []struct {
+            ValidatorIndex uint64 `json:"validator_index,string"`
+            Reward         int64  `json:"reward,string"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(body, &out); err != nil {
+        zap.L().Error("decoding sync committee rewards failed", zap.Error(err))
+        return domain.SyncCommitteeRewards{}, err
+    }
+
+    rewards := domain.SyncCommitteeRewards{
+        Finality: out.Finality,
+        Slot:     slot,
+        Status:   domain.SlotStatusProposed,
+        Rewards:  make([]domain.SyncCommitteeReward, 0, len(out.Data)),
+    }
+    for _, r := range out.Data {
+        rewards.Rewards = append(rewards.Rewards, domain.SyncCommitteeReward{ValidatorIndex: r.ValidatorIndex, Reward: r.Reward})
+    }
+    return rewards, nil
+}
@@ -3,47 +3,51 @@ package execution
 import (
     "time"
 
-    lru "github.com/hashicorp/golang-lru"
+    "eth_validator_api/internal/adapter/cache"
     "eth_validator_api/internal/domain"
 )
 
 type BlockRewardCache struct {
-    lruCache *lru.Cache
-    ttl      time.Duration
+    cache *cache.FinalityCache[rewardKey, domain.BlockReward]
 }
 
-type cacheEntry struct {
-    reward domain.BlockReward
-    ts     time.Time
+type rewardKey struct {
+    slot   uint64
+    method domain.RewardMethod
 }
 
-func NewBlockRewardCache(maxEntries int, ttl time.Duration) (*BlockRewardCache, error) {
-    c, err := lru.New(maxEntries)
+func NewBlockRewardCache(maxEntries int, unfinalizedTTL time.Duration) (*BlockRewardCache, error) {
+    c, err := cache.NewFinalityCache[rewardKey, domain.BlockReward](maxEntries, unfinalizedTTL)
     if err != nil {
         return nil, err
     }
-    return &BlockRewardCache{
-        lruCache: c,
-        ttl:      ttl,
-    }, nil
+    return &BlockRewardCache{cache: c}, nil
 }
 
-func (c *BlockRewardCache) Get(slot uint64) (domain.BlockReward, bool) {
-    raw, ok := c.lruCache.Get(slot)
-    if !ok {
-        return domain.BlockReward{}, false
-    }
-    e := raw.(cacheEntry)
-    if time.Since(e.ts) > c.ttl {
-        c.lruCache.Remove(slot)
-        return domain.BlockReward{}, false
+func (c *BlockRewardCache) Get(slot uint64, method domain.RewardMethod) (domain.BlockReward, bool) {
+    return c.cache.Get(rewardKey{slot: slot, method: method})
+}
+
+func (c *BlockRewardCache) Add(slot uint64, method domain.RewardMethod, reward domain.BlockReward) {
+    c.cache.Add(rewardKey{slot: slot, method: method}, reward, reward.Finalized)
+}
+
+type BlockFeesCache struct {
+    cache *cache.FinalityCache[uint64, domain.BlockFees]
+}
+
+func NewBlockFeesCache(maxEntries int, unfinalizedTTL time.Duration) (*BlockFeesCache, error) {
+    c, err := cache.NewFinalityCache[uint64, domain.BlockFees](maxEntries, unfinalizedTTL)
+    if err != nil {
+        return nil, err
     }
-    return e.reward, true
+    return &BlockFeesCache{cache: c}, nil
+}
+
+func (c *BlockFeesCache) Get(slot uint64) (domain.BlockFees, bool) {
+    return c.cache.Get(slot)
 }
 
-func (c *BlockRewardCache) Add(slot uint64, reward domain.BlockReward) {
-    c.lruCache.Add(slot, cacheEntry{
-        reward: reward,
-        ts:     time.Now(),
-    })
+func (c *BlockFeesCache) Add(slot uint64, fees domain.BlockFees) {
+    c.cache.Add(slot, fees, fees.Finalized)
 }
@@ -2,13 +2,15 @@ package execution
 
 import (
     "context"
+    "encoding/json"
     "math/big"
     "time"
-    "regexp"
-    
+
 
     "go.uber.org/zap"
+    "github.com/ethereum/go-ethereum"
     "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/params"
     "github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -21,13 +23,15 @@ import (
     
 )
 
-var mevRegex = regexp.MustCompile(`(?i)(flashbots|titanbuilder|eden|mev-boost)`)
-
+var (
+    _ port.BlockRewardClient = (*ExecutionClient)(nil)
+    _ port.BlockFeesClient   = (*ExecutionClient)(nil)
+)
 
 type ExecutionClient struct {
 	rpcClient *rpc.Client
     ethClient *ethclient.Client
-    mevRelays map[common.Address]struct{}
+    builders   port.BuilderRegistry
     maxRetries int
     backoff    time.Duration
 }
@@ -37,80 +41,270 @@ type ExecutionClient struct {
 func NewExecutionClient(
 	rpcHTTP *rpc.Client,
     ethHTTP *ethclient.Client,
-    mevAddrs []string,
+    builders port.BuilderRegistry,
     retryMaxRetries int,
     retryBackoff time.Duration,
-) (port.BlockRewardClient, error) {
-    relayMap := make(map[common.Address]struct{}, len(mevAddrs))
-    for _, hex := range mevAddrs {
-        relayMap[common.HexToAddress(hex)] = struct{}{}
-    }
+) (*ExecutionClient, error) {
     return &ExecutionClient{
 		rpcClient: rpcHTTP,
         ethClient: ethHTTP,
-        mevRelays: relayMap,
+        builders:   builders,
         maxRetries: retryMaxRetries,
         backoff:    retryBackoff,
     }, nil
 }
 
 
-func (ec *ExecutionClient) GetBlockReward(ctx context.Context, slot uint64) (domain.BlockReward, error) {
-    if slot == 0 {
-        return domain.BlockReward{Status: "vanilla", Reward: 0}, nil
+
+func (ec *ExecutionClient) GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error) {
+    number := block.BlockNumber
+    if number == 0 {
+        genesis := domain.BlockReward{
+            Finality:      block.Finality,
+            Slot:          block.Slot,
+            ProposerIndex: block.ProposerIndex,
+            Status:        "vanilla",
+            Method:        method,
+        }
+        genesis.SetReward(new(big.Int))
+        return genesis, nil
     }
 
-    var head uint64
-    if err := retry.Do(ctx, ec.maxRetries, ec.backoff, func() error {
-        var err error
-        head, err = ec.ethClient.BlockNumber(ctx)
-        return err
-    }); err != nil {
-        zap.L().Error("failed to fetch head slot", zap.Error(err))
-        return domain.BlockReward{}, err
+    // The execution node may be a different node than the beacon one, and a
+    // lagging or forked node would answer a block number with another block.
+    // Everything below is read by the hash the beacon block committed to.
+    execBlock, err := ec.fetchBlock(ctx, common.HexToHash(block.BlockHash))
+    if err != nil {
+        zap.L().Error("block not found", zap.Uint64("slot", block.Slot), zap.Uint64("block", number), zap.Error(err))
+        return domain.BlockReward{}, errors.ErrSlotNotFound
+    }
+    header := execBlock.header
+
+    status := "vanilla"
+    var builderName string
+    if ec.builders != nil {
+        builderName = ec.builders.Identify(header.Extra, header.Coinbase.Hex())
+    }
+    if builderName != "" {
+        status = "mev"
+    }
+
+    reward := domain.BlockReward{
+        Finality:      block.Finality,
+        Slot:          block.Slot,
+        ProposerIndex: block.ProposerIndex,
+        BlockNumber:   number,
+        BlockHash:     block.BlockHash,
+        Status:        status,
+        Method:        method,
+        FeeRecipient:  block.FeeRecipient,
+        Builder:       builderName,
+    }
+
+    // With MEV-boost the builder is the coinbase, and the beacon payload's
+    // fee recipient is the builder too. The builder pays the proposer in a
+    // transaction of its own, so the balance delta below is the builder's
+    // profit, and the payment's recipient is the proposer's fee recipient.
+    if payment := findProposerPayment(execBlock.transactions, header.Coinbase); payment != nil {
+        reward.Status = "mev"
+        reward.BuilderAddress = header.Coinbase.Hex()
+        reward.FeeRecipient = payment.to.Hex()
+        reward.ProposerPaymentWei = payment.value.String()
+        reward.ProposerPaymentTx = payment.hash
     }
-    if slot > head {
-        return domain.BlockReward{}, errors.ErrSlotInFuture
+
+    switch method {
+    case domain.RewardMethodReceipts:
+        feesWei, err := ec.priorityFees(ctx, execBlock.hash, header)
+        if err != nil {
+            return domain.BlockReward{}, err
+        }
+        reward.SetReward(feesWei)
+
+    case domain.RewardMethodAuto:
+        balanceWei, withdrawalsWei, balanceErr := ec.balanceDiff(ctx, execBlock)
+        feesWei, feesErr := ec.priorityFees(ctx, execBlock.hash, header)
+        if balanceErr == nil {
+            reward.SetWithdrawals(withdrawalsWei)
+        }
+        switch {
+        case feesErr == nil && balanceErr == nil:
+            reward.SetReward(feesWei)
+            reward.Method = domain.RewardMethodReceipts
+            reward.CrossCheck = &domain.RewardCrossCheck{
+                BalanceWei:  balanceWei.String(),
+                ReceiptsWei: feesWei.String(),
+                Match:       balanceWei.Cmp(feesWei) == 0,
+            }
+        case feesErr == nil:
+            zap.L().Warn("balance diff unavailable, using receipts only", zap.Uint64("block", number), zap.Error(balanceErr))
+            reward.SetReward(feesWei)
+            reward.Method = domain.RewardMethodReceipts
+        case balanceErr == nil:
+            zap.L().Warn("receipts unavailable, using balance diff only", zap.Uint64("block", number), zap.Error(feesErr))
+            reward.SetReward(balanceWei)
+            reward.Method = domain.RewardMethodBalance
+        default:
+            return domain.BlockReward{}, feesErr
+        }
+
+    case domain.RewardMethodTrace:
+        breakdown, netWei, err := ec.traceCoinbase(ctx, execBlock.hash, header, reward.ProposerPaymentTx)
+        if err != nil {
+            return domain.BlockReward{}, err
+        }
+        reward.SetReward(netWei)
+        reward.SetWithdrawals(coinbaseWithdrawals(execBlock))
+        reward.Breakdown = breakdown
+
+    default:
+        rewardWei, withdrawalsWei, err := ec.balanceDiff(ctx, execBlock)
+        if err != nil {
+            return domain.BlockReward{}, err
+        }
+        reward.SetReward(rewardWei)
+        reward.SetWithdrawals(withdrawalsWei)
     }
 
+    return reward, nil
+}
+
+func (ec *ExecutionClient) GetBlockFees(ctx context.Context, block domain.SlotBlock) (domain.BlockFees, error) {
+    fees := domain.BlockFees{
+        Finality:      block.Finality,
+        Slot:          block.Slot,
+        ProposerIndex: block.ProposerIndex,
+        BlockNumber:   block.BlockNumber,
+        BlockHash:     block.BlockHash,
+        Status:        domain.SlotStatusProposed,
+    }
+    // Pre-merge slots carry no execution payload.
+    if block.BlockNumber == 0 {
+        return fees, nil
+    }
+
+    hash := common.HexToHash(block.BlockHash)
+    header, err := ec.fetchHeader(ctx, hash)
+    if err != nil {
+        zap.L().Error("header not found", zap.Uint64("slot", block.Slot), zap.Uint64("block", block.BlockNumber), zap.Error(err))
+        return domain.BlockFees{}, errors.ErrSlotNotFound
+    }
+    receipts, err := ec.fetchReceipts(ctx, hash)
+    if err != nil {
+        return domain.BlockFees{}, err
+    }
+    priorityWei := sumPriorityFees(receipts, header)
+
+    fees.GasLimit = header.GasLimit
+    fees.GasUsed = header.GasUsed
+    fees.PriorityFeesWei = priorityWei.String()
+
+    burnt := new(big.Int)
+    if header.BaseFee != nil {
+        burnt.Mul(header.BaseFee, new(big.Int).SetUint64(header.GasUsed))
+        fees.BaseFeePerGasWei = header.BaseFee.String()
+    }
+    fees.BurntFeesWei = burnt.String()
+
+    totalBurnt := new(big.Int).Set(burnt)
+    if header.ExcessBlobGas != nil && header.BlobGasUsed != nil {
+        fees.BlobGasUsed = *header.BlobGasUsed
+        fees.ExcessBlobGas = *header.ExcessBlobGas
+        blobBurnt := new(big.Int)
+        // The blob base fee's update fraction changes with blob parameter
+        // forks, so it is taken from the node rather than recomputed. Only
+        // blob transactions' receipts carry it; a block without blobs burns
+        // nothing and its blob base fee is left out.
+        if blobBaseFee := blobGasPrice(receipts); blobBaseFee != nil {
+            blobBurnt.Mul(blobBaseFee, new(big.Int).SetUint64(*header.BlobGasUsed))
+            fees.BlobBaseFeeWei = blobBaseFee.String()
+        }
+        fees.BlobBurntFeesWei = blobBurnt.String()
+        totalBurnt.Add(totalBurnt, blobBurnt)
+    }
+    fees.TotalBurntWei = totalBurnt.String()
+
+    return fees, nil
+}
+
+func (ec *ExecutionClient) fetchHeader(ctx context.Context, hash common.Hash) (*types.Header, error) {
     var header *types.Header
-    if err := retry.Do(ctx, ec.maxRetries, ec.backoff, func() error {
+    err := retry.Do(ctx, ec.maxRetries, ec.backoff, func() error {
         var err error
-        header, err = ec.ethClient.HeaderByNumber(ctx, big.NewInt(int64(slot)))
+        header, err = ec.ethClient.HeaderByHash(ctx, hash)
         return err
+    })
+    return header, err
+}
+
+// executionBlock is a block fetched once with its transactions, which the
+// reward needs besides the header for the proposer payment and withdrawals.
+// hash is the hash it was fetched by, for the calls that follow.
+type executionBlock struct {
+    hash         common.Hash
+    header       *types.Header
+    transactions []blockTransaction
+    withdrawals  []*types.Withdrawal
+}
+
+func (ec *ExecutionClient) fetchBlock(ctx context.Context, hash common.Hash) (*executionBlock, error) {
+    var raw json.RawMessage
+    if err := retry.Do(ctx, ec.maxRetries, ec.backoff, func() error {
+        return ec.rpcClient.CallContext(ctx, &raw, "eth_getBlockByHash", hash, true)
     }); err != nil {
-        zap.L().Error("header not found", zap.Uint64("slot", slot), zap.Error(err))
-        return domain.BlockReward{}, errors.ErrSlotNotFound
+        return nil, err
+    }
+    if len(raw) == 0 || string(raw) == "null" {
+        return nil, ethereum.NotFound
     }
 
-    status := "vanilla"
-    if mevRegex.Match(header.Extra) {
-        status = "mev"
+    block := &executionBlock{hash: hash, header: new(types.Header)}
+    if err := json.Unmarshal(raw, block.header); err != nil {
+        return nil, err
     }
-    if _, ok := ec.mevRelays[header.Coinbase]; ok {
-        status = "mev"
+    var body struct {
+        Transactions []blockTransaction `json:"transactions"`
+        Withdrawals  []*types.Withdrawal `json:"withdrawals"`
     }
+    if err := json.Unmarshal(raw, &body); err != nil {
+        return nil, err
+    }
+    block.transactions = body.Transactions
+    block.withdrawals = body.Withdrawals
+    return block, nil
+}
 
-    hexSlotPrev := hexutil.EncodeUint64(slot - 1)
-    hexSlot := hexutil.EncodeUint64(slot)
+// balanceDiff returns the change of the coinbase balance over the block,
+// less the withdrawals credited to the coinbase, which are returned apart.
+// Withdrawals are applied at the end of the block and are not a reward.
+// Both balances are read by block hash (EIP-1898), the one before from the
+// parent, so they belong to the same chain as the block.
+func (ec *ExecutionClient) balanceDiff(ctx context.Context, block *executionBlock) (*big.Int, *big.Int, error) {
+    header := block.header
     addr := header.Coinbase.Hex()
 
     batch := []rpc.BatchElem{
         {
             Method: "eth_getBalance",
-            Args:   []interface{}{addr, hexSlotPrev},
+            Args:   []interface{}{addr, rpc.BlockNumberOrHashWithHash(header.ParentHash, false)},
             Result: new(string),
         },
         {
             Method: "eth_getBalance",
-            Args:   []interface{}{addr, hexSlot},
+            Args:   []interface{}{addr, rpc.BlockNumberOrHashWithHash(block.hash, false)},
             Result: new(string),
         },
     }
 
     if err := ec.rpcClient.BatchCallContext(ctx, batch); err != nil {
         zap.L().Error("batch balance call failed", zap.Error(err))
-        return domain.BlockReward{}, err
+        return nil, nil, err
+    }
+    for _, elem := range batch {
+        if elem.Error != nil {
+            zap.L().Error("balance call failed", zap.Error(elem.Error))
+            return nil, nil, elem.Error
+        }
     }
 
     beforeStr := *batch[0].Result.(*string)
@@ -118,19 +312,114 @@ func (ec *ExecutionClient) GetBlockReward(ctx context.Context, slot uint64) (dom
 
     beforeWei, err := hexutil.DecodeBig(beforeStr)
     if err != nil {
-        return domain.BlockReward{}, err
+        return nil, nil, err
     }
     afterWei, err := hexutil.DecodeBig(afterStr)
     if err != nil {
-        return domain.BlockReward{}, err
+        return nil, nil, err
     }
 
-    rewardWei := new(big.Int).Sub(afterWei, beforeWei)
-    rewardGwei := new(big.Int).Div(rewardWei, big.NewInt(1e9)).Uint64()
+    withdrawalsWei := coinbaseWithdrawals(block)
+    diff := new(big.Int).Sub(afterWei, beforeWei)
+    return diff.Sub(diff, withdrawalsWei), withdrawalsWei, nil
+}
 
-    return domain.BlockReward{
-        Status: status,
-        Reward: float64(rewardGwei),
-    }, nil
+// coinbaseWithdrawals adds up the withdrawals credited to the coinbase, in
+// wei.
+func coinbaseWithdrawals(block *executionBlock) *big.Int {
+    total := new(big.Int)
+    for _, w := range block.withdrawals {
+        if w.Address != block.header.Coinbase {
+            continue
+        }
+        // Withdrawal amounts are in gwei.
+        amount := new(big.Int).SetUint64(w.Amount)
+        total.Add(total, amount.Mul(amount, big.NewInt(params.GWei)))
+    }
+    return total
+}
+
+type blockTransaction struct {
+    Hash  string          `json:"hash"`
+    From  common.Address  `json:"from"`
+    To    *common.Address `json:"to"`
+    Value *hexutil.Big    `json:"value"`
+}
+
+type proposerPayment struct {
+    to    common.Address
+    value *big.Int
+    hash  string
 }
 
+// findProposerPayment takes the last transaction the coinbase sent with a
+// value as the builder's payment to the proposer. Builders put it at the
+// end of the block. The relay's proposer fee recipient, when there is one,
+// is checked against it later.
+func findProposerPayment(transactions []blockTransaction, coinbase common.Address) *proposerPayment {
+    for i := len(transactions) - 1; i >= 0; i-- {
+        tx := transactions[i]
+        if tx.From != coinbase || tx.To == nil || *tx.To == coinbase || tx.Value == nil || tx.Value.ToInt().Sign() <= 0 {
+            continue
+        }
+        return &proposerPayment{to: *tx.To, value: tx.Value.ToInt(), hash: tx.Hash}
+    }
+    return nil
+}
+
+type blockReceipt struct {
+    From              common.Address  `json:"from"`
+    GasUsed           hexutil.Uint64  `json:"gasUsed"`
+    EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+    BlobGasUsed       *hexutil.Uint64 `json:"blobGasUsed"`
+    BlobGasPrice      *hexutil.Big    `json:"blobGasPrice"`
+}
+
+func (ec *ExecutionClient) fetchReceipts(ctx context.Context, hash common.Hash) ([]blockReceipt, error) {
+    var receipts []blockReceipt
+    if err := retry.Do(ctx, ec.maxRetries, ec.backoff, func() error {
+        return ec.rpcClient.CallContext(ctx, &receipts, "eth_getBlockReceipts", hash)
+    }); err != nil {
+        zap.L().Error("block receipts call failed", zap.Stringer("block", hash), zap.Error(err))
+        return nil, err
+    }
+    return receipts, nil
+}
+
+func (ec *ExecutionClient) priorityFees(ctx context.Context, hash common.Hash, header *types.Header) (*big.Int, error) {
+    receipts, err := ec.fetchReceipts(ctx, hash)
+    if err != nil {
+        return nil, err
+    }
+    return sumPriorityFees(receipts, header), nil
+}
+
+// sumPriorityFees adds up what the fee recipient earns from the block's
+// transactions: Σ (effectiveGasPrice − baseFee) × gasUsed.
+func sumPriorityFees(receipts []blockReceipt, header *types.Header) *big.Int {
+    baseFee := new(big.Int)
+    if header.BaseFee != nil {
+        baseFee.Set(header.BaseFee)
+    }
+
+    total := new(big.Int)
+    for _, r := range receipts {
+        if r.EffectiveGasPrice == nil {
+            continue
+        }
+        tip := new(big.Int).Sub(r.EffectiveGasPrice.ToInt(), baseFee)
+        total.Add(total, tip.Mul(tip, new(big.Int).SetUint64(uint64(r.GasUsed))))
+    }
+    return total
+}
+
+// blobGasPrice is the blob base fee of the block, as reported in the
+// receipts of its blob transactions. Nil if the block has none.
+func blobGasPrice(receipts []blockReceipt) *big.Int {
+    for _, r := range receipts {
+        if r.BlobGasPrice != nil {
+            return r.BlobGasPrice.ToInt()
+        }
+    }
+    return nil
+}
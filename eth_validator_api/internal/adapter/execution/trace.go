@@ -0,0 +1,152 @@
+package execution
+
+import (
+    "context"
+    stderrors "errors"
+    "math/big"
+
+    "go.uber.org/zap"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/rpc"
+
+    "eth_validator_api/internal/domain"
+    "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/retry"
+)
+
+const rpcMethodNotFound = -32601
+
+type callFrame struct {
+    Type  string         `json:"type"`
+    From  common.Address `json:"from"`
+    To    common.Address `json:"to"`
+    Value *hexutil.Big   `json:"value"`
+    Error string         `json:"error"`
+    Calls []callFrame    `json:"calls"`
+}
+
+type txTrace struct {
+    TxHash string    `json:"txHash"`
+    Result callFrame `json:"result"`
+}
+
+// coinbaseFlows accumulates the value moved in and out of the coinbase by
+// the block's calls.
+type coinbaseFlows struct {
+    coinbase common.Address
+    direct   *big.Int
+    internal *big.Int
+    payment  *big.Int
+}
+
+// traceCoinbase attributes every change of the coinbase balance in a block
+// to fees, top-level transfers, internal calls or the proposer payment, using
+// debug_traceBlockByHash with the callTracer. It returns the breakdown and
+// the net change, which excludes withdrawals.
+func (ec *ExecutionClient) traceCoinbase(ctx context.Context, hash common.Hash, header *types.Header, paymentTx string) (*domain.CoinbaseBreakdown, *big.Int, error) {
+    var traces []txTrace
+    if err := retry.Do(ctx, ec.maxRetries, ec.backoff, func() error {
+        err := ec.rpcClient.CallContext(ctx, &traces, "debug_traceBlockByHash", hash,
+            map[string]interface{}{"tracer": "callTracer"})
+        // A node without the debug namespace won't grow one on a retry.
+        var rpcErr rpc.Error
+        if stderrors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcMethodNotFound {
+            return retry.Permanent(errors.ErrTracingUnsupported)
+        }
+        return err
+    }); err != nil {
+        if err == errors.ErrTracingUnsupported {
+            return nil, nil, err
+        }
+        zap.L().Error("block trace call failed", zap.Stringer("block", hash), zap.Error(err))
+        return nil, nil, err
+    }
+
+    receipts, err := ec.fetchReceipts(ctx, hash)
+    if err != nil {
+        return nil, nil, err
+    }
+    fees := coinbaseFees(receipts, header)
+
+    flows := &coinbaseFlows{
+        coinbase: header.Coinbase,
+        direct:   new(big.Int),
+        internal: new(big.Int),
+        payment:  new(big.Int),
+    }
+    for _, tx := range traces {
+        flows.walk(tx.Result, 0, paymentTx != "" && tx.TxHash == paymentTx)
+    }
+
+    net := new(big.Int).Add(fees, flows.direct)
+    net.Add(net, flows.internal)
+    net.Add(net, flows.payment)
+
+    return &domain.CoinbaseBreakdown{
+        FeesWei:            fees.String(),
+        DirectTransfersWei: flows.direct.String(),
+        InternalCallsWei:   flows.internal.String(),
+        ProposerPaymentWei: flows.payment.String(),
+    }, net, nil
+}
+
+// coinbaseFees is the priority fees the coinbase earned less the gas and
+// blob gas it paid for its own transactions.
+func coinbaseFees(receipts []blockReceipt, header *types.Header) *big.Int {
+    baseFee := new(big.Int)
+    if header.BaseFee != nil {
+        baseFee.Set(header.BaseFee)
+    }
+
+    total := new(big.Int)
+    for _, r := range receipts {
+        if r.EffectiveGasPrice == nil {
+            continue
+        }
+        gas := new(big.Int).SetUint64(uint64(r.GasUsed))
+        tip := new(big.Int).Sub(r.EffectiveGasPrice.ToInt(), baseFee)
+        total.Add(total, tip.Mul(tip, gas))
+        if r.From == header.Coinbase {
+            total.Sub(total, gas.Mul(gas, r.EffectiveGasPrice.ToInt()))
+            if r.BlobGasUsed != nil && r.BlobGasPrice != nil {
+                blobGas := new(big.Int).SetUint64(uint64(*r.BlobGasUsed))
+                total.Sub(total, blobGas.Mul(blobGas, r.BlobGasPrice.ToInt()))
+            }
+        }
+    }
+    return total
+}
+
+func (f *coinbaseFlows) walk(frame callFrame, depth int, isPayment bool) {
+    // A reverted frame moves no value, and neither do its subcalls.
+    if frame.Error != "" {
+        return
+    }
+    // DELEGATECALL and STATICCALL report a value they don't transfer;
+    // CALLCODE sends it back to the caller itself.
+    switch frame.Type {
+    case "DELEGATECALL", "STATICCALL", "CALLCODE":
+    default:
+        if frame.Value != nil && frame.From != frame.To {
+            value := frame.Value.ToInt()
+            target := f.internal
+            if depth == 0 {
+                target = f.direct
+                if isPayment {
+                    target = f.payment
+                }
+            }
+            if frame.To == f.coinbase {
+                target.Add(target, value)
+            }
+            if frame.From == f.coinbase {
+                target.Sub(target, value)
+            }
+        }
+    }
+    for _, call := range frame.Calls {
+        f.walk(call, depth+1, false)
+    }
+}
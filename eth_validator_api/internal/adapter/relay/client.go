@@ -0,0 +1,142 @@
+package relay
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "eth_validator_api/internal/domain"
+    "eth_validator_api/internal/port"
+    "eth_validator_api/internal/retry"
+)
+
+const payloadDeliveredPath = "/relay/v1/data/bidtraces/proposer_payload_delivered?slot=%d"
+
+var _ port.RelayClient = (*RelayClient)(nil)
+
+// RelayClient queries the MEV-boost relays' data API for the payloads they
+// delivered. Relays are queried in parallel under one deadline for the whole
+// lookup, retries included.
+type RelayClient struct {
+    httpClient *http.Client
+    relays     []string
+    maxRetries int
+    backoff    time.Duration
+    timeout    time.Duration
+}
+
+func NewRelayClient(relayURLs []string, maxRetries int, backoff time.Duration, requestTimeout time.Duration) (*RelayClient, error) {
+    relays := make([]string, 0, len(relayURLs))
+    for _, u := range relayURLs {
+        if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+            return nil, fmt.Errorf("invalid relay url %q", u)
+        }
+        relays = append(relays, strings.TrimRight(u, "/"))
+    }
+    return &RelayClient{
+        httpClient: &http.Client{Timeout: requestTimeout},
+        relays:     relays,
+        maxRetries: maxRetries,
+        backoff:    backoff,
+        timeout:    requestTimeout,
+    }, nil
+}
+
+// GetDeliveredPayloads returns the deliveries of the relays that answered.
+// If any relay failed, its error is returned along with them, since the
+// delivering relay may be the one missing.
+func (rc *RelayClient) GetDeliveredPayloads(ctx context.Context, slot uint64) ([]domain.RelayDelivery, error) {
+    if len(rc.relays) == 0 {
+        return nil, nil
+    }
+    ctx, cancel := context.WithTimeout(ctx, rc.timeout)
+    defer cancel()
+
+    results := make([][]domain.RelayDelivery, len(rc.relays))
+    errs := make([]error, len(rc.relays))
+    var wg sync.WaitGroup
+    for i, relay := range rc.relays {
+        wg.Add(1)
+        go func(i int, relay string) {
+            defer wg.Done()
+            results[i], errs[i] = rc.fetchDelivered(ctx, relay, slot)
+        }(i, relay)
+    }
+    wg.Wait()
+
+    var deliveries []domain.RelayDelivery
+    var lastErr error
+    for i, relay := range rc.relays {
+        if errs[i] != nil {
+            zap.L().Warn("relay query failed", zap.String("relay", relay), zap.Uint64("slot", slot), zap.Error(errs[i]))
+            lastErr = fmt.Errorf("relay %s: %w", relay, errs[i])
+            continue
+        }
+        deliveries = append(deliveries, results[i]...)
+    }
+    return deliveries, lastErr
+}
+
+func (rc *RelayClient) fetchDelivered(ctx context.Context, relay string, slot uint64) ([]domain.RelayDelivery, error) {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(relay+payloadDeliveredPath, slot), nil)
+    if err != nil {
+        return nil, err
+    }
+
+    var body []byte
+    var status int
+    err = retry.Do(ctx, rc.maxRetries, rc.backoff, func() error {
+        resp, err := rc.httpClient.Do(req)
+        if err != nil {
+            return err
+        }
+        defer resp.Body.Close()
+
+        body, _ = io.ReadAll(resp.Body)
+        status = resp.StatusCode
+        if status >= 500 || status == 429 {
+            return fmt.Errorf("transient status %d", status)
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    if status != http.StatusOK {
+        return nil, fmt.Errorf("relay returned %d", status)
+    }
+
+    var traces []struct {
+        Slot                 uint64 `json:"slot,string"`
+        BlockHash            string `json:"block_hash"`
+        BuilderPubkey        string `json:"builder_pubkey"`
+        ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+        Value                string `json:"value"`
+    }
+    if err := json.Unmarshal(body, &traces); err != nil {
+        return nil, err
+    }
+
+    deliveries := make([]domain.RelayDelivery, 0, len(traces))
+    for _, t := range traces {
+        // Only trust traces for the slot that was asked for.
+        if t.Slot != slot {
+            continue
+        }
+        deliveries = append(deliveries, domain.RelayDelivery{
+            Relay:                relay,
+            BlockHash:            t.BlockHash,
+            BuilderPubkey:        t.BuilderPubkey,
+            ProposerFeeRecipient: t.ProposerFeeRecipient,
+            ValueWei:             t.Value,
+        })
+    }
+    return deliveries, nil
+}
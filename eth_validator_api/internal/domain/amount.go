@@ -0,0 +1,56 @@
+package domain
+
+import (
+    "math/big"
+    "strings"
+)
+
+const (
+    GweiDecimals = 9
+    EthDecimals  = 18
+)
+
+// FormatWei renders wei in a unit with the given number of decimals as an
+// exact decimal string: 1500000000 wei at 18 decimals is "0.0000000015".
+// Trailing zeros are dropped and negative values keep their sign.
+func FormatWei(wei *big.Int, decimals int) string {
+    if wei == nil {
+        return "0"
+    }
+    digits := new(big.Int).Abs(wei).String()
+    if len(digits) <= decimals {
+        digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+    }
+    whole := digits[:len(digits)-decimals]
+    frac := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+    s := whole
+    if frac != "" {
+        s += "." + frac
+    }
+    if wei.Sign() < 0 {
+        s = "-" + s
+    }
+    return s
+}
+
+// SetReward fills the wei, gwei and ETH renderings of the reward.
+func (r *BlockReward) SetReward(wei *big.Int) {
+    r.RewardWei = wei.String()
+    r.RewardGwei = FormatWei(wei, GweiDecimals)
+    r.RewardEth = FormatWei(wei, EthDecimals)
+}
+
+// SetWithdrawals records the withdrawals credited to the coinbase, if any.
+func (r *BlockReward) SetWithdrawals(wei *big.Int) {
+    if wei.Sign() > 0 {
+        r.WithdrawalsWei = wei.String()
+    }
+}
+
+// SetTotal fills the wei, gwei and ETH renderings of the total.
+func (r *ProposalReward) SetTotal(wei *big.Int) {
+    r.TotalWei = wei.String()
+    r.TotalGwei = FormatWei(wei, GweiDecimals)
+    r.TotalEth = FormatWei(wei, EthDecimals)
+}
\ No newline at end of file
@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+    "math/big"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+)
+
+func TestFormatWei(t *testing.T) {
+    cases := []struct {
+        wei      string
+        decimals int
+        want     string
+    }{
+        {"0", domain.GweiDecimals, "0"},
+        {"1", domain.GweiDecimals, "0.000000001"},
+        {"1000000000", domain.GweiDecimals, "1"},
+        {"3187542500123456789", domain.GweiDecimals, "3187542500.123456789"},
+        {"3187542500123456789", domain.EthDecimals, "3.187542500123456789"},
+        {"-1500000000000000000", domain.EthDecimals, "-1.5"},
+        {"-1", domain.GweiDecimals, "-0.000000001"},
+        {"123000000000000000000000", domain.EthDecimals, "123000"},
+    }
+    for _, c := range cases {
+        wei, _ := new(big.Int).SetString(c.wei, 10)
+        if got := domain.FormatWei(wei, c.decimals); got != c.want {
+            t.Errorf("FormatWei(%s, %d) = %s, esperaba %s", c.wei, c.decimals, got, c.want)
+        }
+    }
+}
+
+func TestBlockReward_SetRewardNegative(t *testing.T) {
+    var r domain.BlockReward
+    r.SetReward(big.NewInt(-2_500_000_000))
+    if r.RewardWei != "-2500000000" || r.RewardGwei != "-2.5" || r.RewardEth != "-0.0000000025" {
+        t.Errorf("importes inesperados: %+v", r)
+    }
+}
@@ -0,0 +1,26 @@
+package domain
+
+// BlockFees breaks down where the fees of an execution block went: the
+// EIP-1559 base fee and EIP-4844 blob fees are burned, the priority fees go
+// to the fee recipient.
+type BlockFees struct {
+    Finality
+    Slot          uint64 `json:"slot"`
+    ProposerIndex uint64 `json:"proposer_index"`
+    BlockNumber   uint64 `json:"block_number,omitempty"`
+    BlockHash     string `json:"block_hash,omitempty"`
+    Status        string `json:"status"`
+
+    GasLimit         uint64 `json:"gas_limit"`
+    GasUsed          uint64 `json:"gas_used"`
+    BaseFeePerGasWei string `json:"base_fee_per_gas_wei,omitempty"`
+    BurntFeesWei     string `json:"burnt_fees_wei,omitempty"`
+    PriorityFeesWei  string `json:"priority_fees_wei,omitempty"`
+
+    BlobGasUsed      uint64 `json:"blob_gas_used"`
+    ExcessBlobGas    uint64 `json:"excess_blob_gas"`
+    BlobBaseFeeWei   string `json:"blob_base_fee_wei,omitempty"`
+    BlobBurntFeesWei string `json:"blob_burnt_fees_wei,omitempty"`
+
+    TotalBurntWei string `json:"total_burnt_wei,omitempty"`
+}
\ No newline at end of file
@@ -0,0 +1,7 @@
+package domain
+
+type ChainHead struct {
+    HeadSlot      uint64 `json:"head_slot"`
+    JustifiedSlot uint64 `json:"justified_slot"`
+    FinalizedSlot uint64 `json:"finalized_slot"`
+}
\ No newline at end of file
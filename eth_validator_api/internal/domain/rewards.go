@@ -0,0 +1,75 @@
+package domain
+
+// ConsensusBlockReward is what the proposer earned on the consensus layer
+// for including attestations, the sync aggregate and slashings, in gwei.
+type ConsensusBlockReward struct {
+    Finality
+    Slot              uint64 `json:"slot"`
+    ProposerIndex     uint64 `json:"proposer_index"`
+    Total             uint64 `json:"total_gwei"`
+    Attestations      uint64 `json:"attestations_gwei"`
+    SyncAggregate     uint64 `json:"sync_aggregate_gwei"`
+    ProposerSlashings uint64 `json:"proposer_slashings_gwei"`
+    AttesterSlashings uint64 `json:"attester_slashings_gwei"`
+}
+
+// ProposalReward is the full income of a block proposal: the consensus
+// layer reward plus the execution layer income of the fee recipient.
+// ExecutionIncomeVerified is false when a builder built the block but no
+// payment to the fee recipient was found on chain, so the income is the
+// relay's claimed value, or 0 without one.
+type ProposalReward struct {
+    Finality
+    Slot          uint64 `json:"slot"`
+    ProposerIndex uint64 `json:"proposer_index"`
+    Status        string `json:"status"`
+
+    Consensus               *ConsensusBlockReward `json:"consensus,omitempty"`
+    Execution               BlockReward           `json:"execution"`
+    ExecutionIncomeWei      string                `json:"execution_income_wei"`
+    ExecutionIncomeVerified bool                  `json:"execution_income_verified"`
+
+    TotalWei  string `json:"total_wei"`
+    TotalGwei string `json:"total_gwei"`
+    TotalEth  string `json:"total_eth"`
+}
+
+// AttestationRewards holds the attestation rewards of a set of validators
+// for an epoch, in gwei. Penalties are negative. IdealRewards lists what a
+// perfect attester would have earned for each effective balance.
+type AttestationRewards struct {
+    Finality
+    Epoch        uint64                   `json:"epoch"`
+    IdealRewards []IdealAttestationReward `json:"ideal_rewards"`
+    TotalRewards []AttestationReward      `json:"total_rewards"`
+}
+
+type AttestationReward struct {
+    ValidatorIndex uint64 `json:"validator_index"`
+    Head           int64  `json:"head_gwei"`
+    Target         int64  `json:"target_gwei"`
+    Source         int64  `json:"source_gwei"`
+    Inactivity     int64  `json:"inactivity_gwei"`
+}
+
+type IdealAttestationReward struct {
+    EffectiveBalance uint64 `json:"effective_balance_gwei"`
+    Head             int64  `json:"head_gwei"`
+    Target           int64  `json:"target_gwei"`
+    Source           int64  `json:"source_gwei"`
+    Inactivity       int64  `json:"inactivity_gwei"`
+}
+
+// SyncCommitteeRewards holds the sync committee rewards paid in the block at
+// a slot, in gwei. Members that didn't sign get a negative reward.
+type SyncCommitteeRewards struct {
+    Finality
+    Slot    uint64                `json:"slot"`
+    Status  string                `json:"status"`
+    Rewards []SyncCommitteeReward `json:"rewards"`
+}
+
+type SyncCommitteeReward struct {
+    ValidatorIndex uint64 `json:"validator_index"`
+    Reward         int64  `json:"reward_gwei"`
+}
\ No newline at end of file
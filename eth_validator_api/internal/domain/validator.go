@@ -1,11 +1,216 @@
 package domain
 
+import "time"
+
+const (
+    SlotStatusProposed = "proposed"
+    SlotStatusMissed   = "missed"
+)
+
+type RewardMethod string
+
+const (
+    RewardMethodBalance  RewardMethod = "balance"
+    RewardMethodReceipts RewardMethod = "receipts"
+    RewardMethodAuto     RewardMethod = "auto"
+    RewardMethodTrace    RewardMethod = "trace"
+)
+
+func ParseRewardMethod(s string) (RewardMethod, bool) {
+    switch RewardMethod(s) {
+    case "":
+        return RewardMethodBalance, true
+    case RewardMethodBalance, RewardMethodReceipts, RewardMethodAuto, RewardMethodTrace:
+        return RewardMethod(s), true
+    }
+    return "", false
+}
+
+const (
+    ValidatorStatusPending   = "pending"
+    ValidatorStatusActive    = "active"
+    ValidatorStatusExiting   = "exiting"
+    ValidatorStatusSlashed   = "slashed"
+    ValidatorStatusWithdrawn = "withdrawn"
+)
+
+// ValidatorStatusFromBeacon collapses the beacon API's validator statuses
+// into the ones on-call cares about. Exited validators count as exiting until
+// their balance can be withdrawn.
+func ValidatorStatusFromBeacon(status string) string {
+    switch status {
+    case "pending_initialized", "pending_queued":
+        return ValidatorStatusPending
+    case "active_ongoing":
+        return ValidatorStatusActive
+    case "active_exiting", "exited_unslashed":
+        return ValidatorStatusExiting
+    case "active_slashed", "exited_slashed":
+        return ValidatorStatusSlashed
+    case "withdrawal_possible", "withdrawal_done":
+        return ValidatorStatusWithdrawn
+    }
+    return status
+}
+
+type Finality struct {
+    Finalized           bool `json:"finalized"`
+    ExecutionOptimistic bool `json:"execution_optimistic"`
+}
+
+type SlotBlock struct {
+    Finality
+    Slot              uint64
+    Missed            bool
+    ProposerIndex     uint64
+    BlockNumber       uint64
+    BlockHash         string
+    FeeRecipient      string
+    Graffiti          string
+    // Only known up front for missed slots, from the proposer duties.
+    ProposerPubkey    string
+    // One bit per committee position, set if that member signed. Nil before
+    // Altair.
+    SyncCommitteeBits []byte
+}
 
 type BlockReward struct {
-    Status string  `json:"status"`
-    Reward float64 `json:"reward_gwei"`
+    Finality
+    Slot          uint64             `json:"slot"`
+    ProposerIndex uint64             `json:"proposer_index"`
+    BlockNumber   uint64             `json:"block_number,omitempty"`
+    BlockHash     string             `json:"block_hash,omitempty"`
+    Status        string             `json:"status"`
+    RewardWei     string             `json:"reward_wei"`
+    RewardGwei    string             `json:"reward_gwei"`
+    RewardEth     string             `json:"reward_eth"`
+    Method        RewardMethod       `json:"method,omitempty"`
+    CrossCheck    *RewardCrossCheck  `json:"cross_check,omitempty"`
+    Breakdown     *CoinbaseBreakdown `json:"breakdown,omitempty"`
+
+    WithdrawalsWei string `json:"withdrawals_wei,omitempty"`
+
+    ProposerPubkey     string `json:"proposer_pubkey,omitempty"`
+    Graffiti           string `json:"graffiti,omitempty"`
+    FeeRecipient       string `json:"fee_recipient,omitempty"`
+    Builder            string `json:"builder,omitempty"`
+    BuilderAddress     string `json:"builder_address,omitempty"`
+    ProposerPaymentWei string `json:"proposer_payment_wei,omitempty"`
+    ProposerPaymentTx  string `json:"proposer_payment_tx,omitempty"`
+
+    Relays []RelayDelivery `json:"relays,omitempty"`
+}
+
+type ProposerIdentity struct {
+    Finality
+    Slot          uint64 `json:"slot"`
+    Status        string `json:"status"`
+    ProposerIndex uint64 `json:"proposer_index"`
+    Pubkey        string `json:"pubkey"`
+    Graffiti      string `json:"graffiti,omitempty"`
+    FeeRecipient  string `json:"fee_recipient,omitempty"`
+}
+
+type RelayDelivery struct {
+    Relay                string `json:"relay"`
+    BlockHash            string `json:"block_hash"`
+    BuilderPubkey        string `json:"builder_pubkey"`
+    ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+    ValueWei             string `json:"value_wei"`
+}
+
+type RewardCrossCheck struct {
+    BalanceWei  string `json:"balance_wei"`
+    ReceiptsWei string `json:"receipts_wei"`
+    Match       bool   `json:"match"`
+}
+
+// CoinbaseBreakdown attributes the coinbase balance change of a block, in
+// signed wei. Withdrawals are reported apart in BlockReward.
+type CoinbaseBreakdown struct {
+    FeesWei            string `json:"fees_wei"`
+    DirectTransfersWei string `json:"direct_transfers_wei"`
+    InternalCallsWei   string `json:"internal_calls_wei"`
+    ProposerPaymentWei string `json:"proposer_payment_wei"`
 }
 
 type SyncDuties struct {
-    Validators []string `json:"validators"`
+    Finality
+    Slot          uint64             `json:"slot"`
+    Status        string             `json:"status"`
+    ProposerIndex uint64             `json:"proposer_index"`
+    Validators    []string           `json:"validators"`
+    Subcommittees []SyncSubcommittee `json:"subcommittees"`
+}
+
+// SyncSubcommittee is one of the four slices of the sync committee. Its
+// members publish their signatures on the gossip subnet of the same number.
+type SyncSubcommittee struct {
+    Subnet  int                   `json:"subnet"`
+    Members []SyncCommitteeMember `json:"members"`
+}
+
+type SyncCommitteeMember struct {
+    ValidatorIndex uint64 `json:"validator_index"`
+    Pubkey         string `json:"pubkey"`
+}
+
+type ValidatorSyncDuty struct {
+    Finality
+    Slot           uint64 `json:"slot"`
+    Period         uint64 `json:"period"`
+    ValidatorIndex uint64 `json:"validator_index"`
+    Pubkey         string `json:"pubkey"`
+    Member         bool   `json:"member"`
+    Positions      []int  `json:"positions"`
+    Subcommittees  []int  `json:"subcommittees"`
+}
+
+// SyncCommitteePreview is the committee of a sync committee period and when
+// that period starts.
+type SyncCommitteePreview struct {
+    Finality
+    Period        uint64             `json:"period"`
+    StartEpoch    uint64             `json:"start_epoch"`
+    StartSlot     uint64             `json:"start_slot"`
+    StartTime     time.Time          `json:"start_time"`
+    Validators    []string           `json:"validators"`
+    Subcommittees []SyncSubcommittee `json:"subcommittees"`
+}
+
+// SyncParticipation splits the sync committee of a slot by whether each
+// position signed the block's sync aggregate.
+type SyncParticipation struct {
+    Finality
+    Slot              uint64              `json:"slot"`
+    Status            string              `json:"status"`
+    Period            uint64              `json:"period"`
+    ParticipationRate float64             `json:"participation_rate"`
+    Participated      []SyncCommitteeSeat `json:"participated"`
+    Missed            []SyncCommitteeSeat `json:"missed"`
+}
+
+type SyncCommitteeSeat struct {
+    Position       int    `json:"position"`
+    ValidatorIndex uint64 `json:"validator_index"`
+    Pubkey         string `json:"pubkey"`
+}
+
+// ValidatorStatus is a validator's record in the state at State. Epochs that
+// haven't been scheduled are the far-future epoch, 2^64-1.
+type ValidatorStatus struct {
+    Finality
+    State                      string `json:"state"`
+    Index                      uint64 `json:"index"`
+    Pubkey                     string `json:"pubkey"`
+    Status                     string `json:"status"`
+    BeaconStatus               string `json:"beacon_status"`
+    Slashed                    bool   `json:"slashed"`
+    BalanceGwei                uint64 `json:"balance_gwei"`
+    EffectiveBalanceGwei       uint64 `json:"effective_balance_gwei"`
+    ActivationEligibilityEpoch uint64 `json:"activation_eligibility_epoch"`
+    ActivationEpoch            uint64 `json:"activation_epoch"`
+    ExitEpoch                  uint64 `json:"exit_epoch"`
+    WithdrawableEpoch          uint64 `json:"withdrawable_epoch"`
+    WithdrawalCredentials      string `json:"withdrawal_credentials"`
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package domain_test
+
+import (
+    "testing"
+
+    "eth_validator_api/internal/domain"
+)
+
+func TestValidatorStatusFromBeacon(t *testing.T) {
+    cases := map[string]string{
+        "pending_initialized": domain.ValidatorStatusPending,
+        "pending_queued":      domain.ValidatorStatusPending,
+        "active_ongoing":      domain.ValidatorStatusActive,
+        "active_exiting":      domain.ValidatorStatusExiting,
+        "exited_unslashed":    domain.ValidatorStatusExiting,
+        "active_slashed":      domain.ValidatorStatusSlashed,
+        "exited_slashed":      domain.ValidatorStatusSlashed,
+        "withdrawal_possible": domain.ValidatorStatusWithdrawn,
+        "withdrawal_done":     domain.ValidatorStatusWithdrawn,
+        "unknown_future":      "unknown_future",
+    }
+    for beacon, want := range cases {
+        if got := domain.ValidatorStatusFromBeacon(beacon); got != want {
+            t.Errorf("ValidatorStatusFromBeacon(%q) = %q, esperaba %q", beacon, got, want)
+        }
+    }
+}
@@ -19,7 +19,16 @@ var (
     ErrSlotInFuture       = &apiError{msg: "slot in future", code: http.StatusBadRequest}
     ErrSlotNotFound       = &apiError{msg: "slot not found", code: http.StatusNotFound}
     ErrSlotTooFarInFuture = &apiError{msg: "slot too far in future", code: http.StatusBadRequest}
+    ErrEpochNotComplete   = &apiError{msg: "epoch not complete", code: http.StatusBadRequest}
+    ErrEpochOutOfRange    = &apiError{msg: "epoch not in the current or next sync committee period", code: http.StatusBadRequest}
+    ErrInvalidValidatorID = &apiError{msg: "invalid validator id", code: http.StatusBadRequest}
+    ErrInvalidState       = &apiError{msg: "invalid state", code: http.StatusBadRequest}
+    ErrNoValidators       = &apiError{msg: "validators required", code: http.StatusBadRequest}
+    ErrRewardsUnavailable = &apiError{msg: "rewards not available", code: http.StatusNotFound}
+    ErrValidatorNotFound  = &apiError{msg: "validator not found", code: http.StatusNotFound}
+    ErrTracingUnsupported = &apiError{msg: "tracing not supported by execution node", code: http.StatusNotImplemented}
 
 	ErrRequestTimeout     = &apiError{"request timed out", http.StatusGatewayTimeout}
+	ErrHeadUnavailable    = &apiError{msg: "chain head not available", code: http.StatusServiceUnavailable}
 	ErrInternal           = &apiError{msg: "internal server error", code: http.StatusInternalServerError}
 )
\ No newline at end of file
@@ -4,71 +4,285 @@ import (
     "encoding/json"
     "net/http"
     "strconv"
+    "strings"
 
     "github.com/go-chi/chi"
     "go.uber.org/zap"
 
+    "eth_validator_api/internal/domain"
     "eth_validator_api/internal/errors"
     "eth_validator_api/internal/usecase"
 )
 
+// UseCases groups the usecases served by the handler.
+type UseCases struct {
+    BlockReward    *usecase.BlockRewardUseCase
+    SyncDuties     *usecase.SyncDutiesUseCase
+    BlockFees      *usecase.BlockFeesUseCase
+    ProposalReward *usecase.ProposalRewardUseCase
+    Attestations   *usecase.AttestationRewardsUseCase
+    SyncRewards    *usecase.SyncRewardsUseCase
+    Proposer       *usecase.ProposerUseCase
+    SyncDuty       *usecase.ValidatorSyncDutyUseCase
+    Participation  *usecase.SyncParticipationUseCase
+    Validator      *usecase.ValidatorStatusUseCase
+}
+
 type Handler struct {
     brUseCase *usecase.BlockRewardUseCase
     sdUseCase *usecase.SyncDutiesUseCase
+    bfUseCase *usecase.BlockFeesUseCase
+    prUseCase *usecase.ProposalRewardUseCase
+    arUseCase *usecase.AttestationRewardsUseCase
+    srUseCase *usecase.SyncRewardsUseCase
+    ppUseCase *usecase.ProposerUseCase
+    vsUseCase *usecase.ValidatorSyncDutyUseCase
+    spUseCase *usecase.SyncParticipationUseCase
+    vlUseCase *usecase.ValidatorStatusUseCase
 }
 
-func NewHandler(br *usecase.BlockRewardUseCase, sd *usecase.SyncDutiesUseCase) *Handler {
-    return &Handler{brUseCase: br, sdUseCase: sd}
+func NewHandler(uc UseCases) *Handler {
+    return &Handler{
+        brUseCase: uc.BlockReward,
+        sdUseCase: uc.SyncDuties,
+        bfUseCase: uc.BlockFees,
+        prUseCase: uc.ProposalReward,
+        arUseCase: uc.Attestations,
+        srUseCase: uc.SyncRewards,
+        ppUseCase: uc.Proposer,
+        vsUseCase: uc.SyncDuty,
+        spUseCase: uc.Participation,
+        vlUseCase: uc.Validator,
+    }
 }
 
 func (h *Handler) Register(r chi.Router) {
     r.Get("/blockreward/{slot}", h.getBlockReward)
+    r.Get("/proposalreward/{slot}", h.getProposalReward)
+    r.Get("/proposer/{slot}", h.getProposer)
+    r.Get("/syncduties/next", h.getNextSyncCommittee)
     r.Get("/syncduties/{slot}", h.getSyncDuties)
+    r.Get("/syncduties/{slot}/validator/{id}", h.getValidatorSyncDuty)
+    r.Get("/syncrewards/{slot}", h.getSyncRewards)
+    r.Post("/syncrewards/{slot}", h.getSyncRewards)
+    r.Get("/syncparticipation/{slot}", h.getSyncParticipation)
+    r.Post("/syncparticipation/{slot}", h.getSyncParticipation)
+    r.Get("/block/{slot}", h.getBlockFees)
+    r.Get("/validator/{id}", h.getValidator)
+    r.Get("/attestationrewards/{epoch}", h.getAttestationRewards)
+    r.Post("/attestationrewards/{epoch}", h.getAttestationRewards)
 }
 
 func (h *Handler) getBlockReward(w http.ResponseWriter, r *http.Request) {
-    slotStr := chi.URLParam(r, "slot")
-    slot, err := strconv.ParseUint(slotStr, 10, 64)
+    slot, ok := parseSlot(w, r)
+    if !ok {
+        return
+    }
+    method, ok := domain.ParseRewardMethod(r.URL.Query().Get("method"))
+    if !ok {
+        writeErrorJSON(w, http.StatusBadRequest, "invalid method")
+        return
+    }
+    result, err := h.brUseCase.Execute(r.Context(), slot, method)
     if err != nil {
-        zap.L().Error("invalid slot param", zap.Error(err))
-        writeErrorJSON(w, http.StatusBadRequest, "invalid slot")
+        writeUseCaseError(w, "block reward", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+func (h *Handler) getProposalReward(w http.ResponseWriter, r *http.Request) {
+    slot, ok := parseSlot(w, r)
+    if !ok {
         return
     }
-    result, err := h.brUseCase.Execute(r.Context(), slot)
+    method, ok := domain.ParseRewardMethod(r.URL.Query().Get("method"))
+    if !ok {
+        writeErrorJSON(w, http.StatusBadRequest, "invalid method")
+        return
+    }
+    result, err := h.prUseCase.Execute(r.Context(), slot, method)
+    if err != nil {
+        writeUseCaseError(w, "proposal reward", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+func (h *Handler) getProposer(w http.ResponseWriter, r *http.Request) {
+    slot, ok := parseSlot(w, r)
+    if !ok {
+        return
+    }
+    result, err := h.ppUseCase.Execute(r.Context(), slot)
     if err != nil {
-        if he, ok := err.(errors.HTTPError); ok {
-            writeErrorJSON(w, he.StatusCode(), he.Error())
+        writeUseCaseError(w, "proposer", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+func (h *Handler) getSyncDuties(w http.ResponseWriter, r *http.Request) {
+    slot, ok := parseSlot(w, r)
+    if !ok {
+        return
+    }
+    if raw := r.URL.Query().Get("epoch"); raw != "" {
+        epoch, err := strconv.ParseUint(raw, 10, 64)
+        if err != nil {
+            writeErrorJSON(w, http.StatusBadRequest, "invalid epoch")
             return
         }
-        zap.L().Error("unexpected block reward error", zap.Error(err))
-        writeErrorJSON(w, http.StatusInternalServerError, "internal error")
+        result, err := h.sdUseCase.ForEpoch(r.Context(), slot, epoch)
+        if err != nil {
+            writeUseCaseError(w, "sync duties", err)
+            return
+        }
+        writeJSON(w, result)
+        return
+    }
+    result, err := h.sdUseCase.Execute(r.Context(), slot)
+    if err != nil {
+        writeUseCaseError(w, "sync duties", err)
         return
     }
     writeJSON(w, result)
-	
 }
 
-func (h *Handler) getSyncDuties(w http.ResponseWriter, r *http.Request) {
-    slotStr := chi.URLParam(r, "slot")
-    slot, err := strconv.ParseUint(slotStr, 10, 64)
+func (h *Handler) getNextSyncCommittee(w http.ResponseWriter, r *http.Request) {
+    result, err := h.sdUseCase.Next(r.Context())
     if err != nil {
-        zap.L().Error("invalid slot param", zap.Error(err))
-        writeErrorJSON(w, http.StatusBadRequest, "invalid slot")
+        writeUseCaseError(w, "next sync committee", err)
         return
     }
-    result, err := h.sdUseCase.Execute(r.Context(), slot)
+    writeJSON(w, result)
+}
+
+func (h *Handler) getValidatorSyncDuty(w http.ResponseWriter, r *http.Request) {
+    slot, ok := parseSlot(w, r)
+    if !ok {
+        return
+    }
+    result, err := h.vsUseCase.Execute(r.Context(), slot, chi.URLParam(r, "id"))
     if err != nil {
-        if he, ok := err.(errors.HTTPError); ok {
-            writeErrorJSON(w, he.StatusCode(), he.Error())
-            return
-        }
-        zap.L().Error("unexpected sync duties error", zap.Error(err))
-        writeErrorJSON(w, http.StatusInternalServerError, "internal error")
+        writeUseCaseError(w, "validator sync duty", err)
         return
     }
     writeJSON(w, result)
 }
 
+func (h *Handler) getSyncRewards(w http.ResponseWriter, r *http.Request) {
+    slot, ok := parseSlot(w, r)
+    if !ok {
+        return
+    }
+    validators, ok := parseValidators(w, r)
+    if !ok {
+        return
+    }
+    result, err := h.srUseCase.Execute(r.Context(), slot, validators)
+    if err != nil {
+        writeUseCaseError(w, "sync rewards", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+func (h *Handler) getSyncParticipation(w http.ResponseWriter, r *http.Request) {
+    slot, ok := parseSlot(w, r)
+    if !ok {
+        return
+    }
+    validators, ok := parseValidators(w, r)
+    if !ok {
+        return
+    }
+    result, err := h.spUseCase.Execute(r.Context(), slot, validators)
+    if err != nil {
+        writeUseCaseError(w, "sync participation", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+func (h *Handler) getBlockFees(w http.ResponseWriter, r *http.Request) {
+    slot, ok := parseSlot(w, r)
+    if !ok {
+        return
+    }
+    result, err := h.bfUseCase.Execute(r.Context(), slot)
+    if err != nil {
+        writeUseCaseError(w, "block fees", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+func (h *Handler) getValidator(w http.ResponseWriter, r *http.Request) {
+    result, err := h.vlUseCase.Execute(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("state"))
+    if err != nil {
+        writeUseCaseError(w, "validator", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+func (h *Handler) getAttestationRewards(w http.ResponseWriter, r *http.Request) {
+    epoch, err := strconv.ParseUint(chi.URLParam(r, "epoch"), 10, 64)
+    if err != nil {
+        zap.L().Error("invalid epoch param", zap.Error(err))
+        writeErrorJSON(w, http.StatusBadRequest, "invalid epoch")
+        return
+    }
+    validators, ok := parseValidators(w, r)
+    if !ok {
+        return
+    }
+    result, err := h.arUseCase.Execute(r.Context(), epoch, validators)
+    if err != nil {
+        writeUseCaseError(w, "attestation rewards", err)
+        return
+    }
+    writeJSON(w, result)
+}
+
+// parseValidators reads validator ids from a comma-separated ?validators=
+// list on GET, or from a JSON array body on POST for large sets.
+func parseValidators(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+    if r.Method == http.MethodPost {
+        var ids []string
+        if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+            writeErrorJSON(w, http.StatusBadRequest, "invalid body")
+            return nil, false
+        }
+        return ids, true
+    }
+    var ids []string
+    for _, v := range r.URL.Query()["validators"] {
+        ids = append(ids, strings.Split(v, ",")...)
+    }
+    return ids, true
+}
+
+func parseSlot(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+    slot, err := strconv.ParseUint(chi.URLParam(r, "slot"), 10, 64)
+    if err != nil {
+        zap.L().Error("invalid slot param", zap.Error(err))
+        writeErrorJSON(w, http.StatusBadRequest, "invalid slot")
+        return 0, false
+    }
+    return slot, true
+}
+
+func writeUseCaseError(w http.ResponseWriter, what string, err error) {
+    if he, ok := err.(errors.HTTPError); ok {
+        writeErrorJSON(w, he.StatusCode(), he.Error())
+        return
+    }
+    zap.L().Error("unexpected "+what+" error", zap.Error(err))
+    writeErrorJSON(w, http.StatusInternalServerError, "internal error")
+}
+
 func writeJSON(w http.ResponseWriter, v interface{}) {
     w.Header().Set("Content-Type", "application/json")
     if err := json.NewEncoder(w).Encode(v) ; err != nil {
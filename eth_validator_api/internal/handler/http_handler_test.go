@@ -20,37 +20,58 @@ import (
 
 type mockBR struct{}
 
-func (m *mockBR) GetBlockReward(ctx context.Context, slot uint64) (domain.BlockReward, error) {
-	return domain.BlockReward{Status: "vanilla", Reward: 1.0}, nil
+func (m *mockBR) GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error) {
+	return domain.BlockReward{Status: "vanilla", RewardWei: "1000000000", RewardGwei: "1", RewardEth: "0.000000001"}, nil
 }
-func (m *mockBR) GetSyncDuties(ctx context.Context, slot uint64) (domain.SyncDuties, error) {
+func (m *mockBR) GetSyncDuties(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error) {
 	return domain.SyncDuties{}, nil
 }
 
 type mockSD struct{}
 
-func (m *mockSD) GetSyncDuties(ctx context.Context, slot uint64) (domain.SyncDuties, error) {
+func (m *mockSD) GetSyncDuties(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error) {
 	return domain.SyncDuties{Validators: []string{"A", "B"}}, nil
 }
-func (m *mockSD) GetBlockReward(ctx context.Context, slot uint64) (domain.BlockReward, error) {
+func (m *mockSD) GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error) {
 	return domain.BlockReward{}, nil
 }
 
+type mockResolver struct{}
+
+func (m *mockResolver) ResolveSlot(ctx context.Context, slot uint64) (domain.SlotBlock, error) {
+	return domain.SlotBlock{Slot: slot, BlockNumber: slot}, nil
+}
+
+type staticHead struct{}
+
+func (h *staticHead) Head() (domain.ChainHead, bool) {
+	return domain.ChainHead{HeadSlot: 1 << 40}, true
+}
+
 type dummyCache struct{}
 
 func (c *dummyCache) Get(slot uint64) (domain.SyncDuties, bool) { return domain.SyncDuties{}, false }
 func (c *dummyCache) Add(slot uint64, d domain.SyncDuties)      {}
 
+type mockPubkeys struct{}
+
+func (m *mockPubkeys) ValidatorPubkey(ctx context.Context, index uint64) (string, error) { return "0xpubkey", nil }
+
+type dummyCacheProposer struct{}
+
+func (c *dummyCacheProposer) Get(slot uint64) (domain.ProposerIdentity, bool) { return domain.ProposerIdentity{}, false }
+func (c *dummyCacheProposer) Add(slot uint64, p domain.ProposerIdentity)      {}
+
 type dummyCacheBR struct{}
-func (c *dummyCacheBR) Get(slot uint64) (domain.BlockReward, bool) { return domain.BlockReward{}, false }
-func (c *dummyCacheBR) Add(slot uint64, d domain.BlockReward) {}
+func (c *dummyCacheBR) Get(slot uint64, method domain.RewardMethod) (domain.BlockReward, bool) { return domain.BlockReward{}, false }
+func (c *dummyCacheBR) Add(slot uint64, method domain.RewardMethod, d domain.BlockReward) {}
 
 func TestHTTPHandler(t *testing.T) {
 	zap.ReplaceGlobals(zap.NewNop())
 
-	brUC := usecase.NewBlockRewardUseCase(&mockBR{}, &dummyCacheBR{})
-	sdUC := usecase.NewSyncDutiesUseCase(&mockSD{}, &dummyCache{})
-	h := handler.NewHandler(brUC, sdUC)
+	brUC := usecase.NewBlockRewardUseCase(&mockResolver{}, &mockBR{}, &dummyCacheBR{}, &staticHead{}, nil, nil)
+	sdUC := usecase.NewSyncDutiesUseCase(usecase.NewProposerUseCase(&mockResolver{}, &mockPubkeys{}, &dummyCacheProposer{}, &staticHead{}), &mockSD{}, &dummyCache{}, &staticHead{}, nil, 0)
+	h := handler.NewHandler(handler.UseCases{BlockReward: brUC, SyncDuties: sdUC})
 
 	r := chi.NewRouter()
 	h.Register(r)
@@ -87,22 +108,22 @@ func TestHTTPHandler(t *testing.T) {
 
 type errorMockClient struct{}
 
-func (m *errorMockClient) GetBlockReward(ctx context.Context, slot uint64) (domain.BlockReward, error) {
-	if slot == 12345 {
+func (m *errorMockClient) GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error) {
+	if block.Slot == 12345 {
 		return domain.BlockReward{}, apierr.ErrSlotNotFound
 	}
 	return domain.BlockReward{}, apierr.ErrSlotInFuture
 }
-func (m *errorMockClient) GetSyncDuties(ctx context.Context, slot uint64) (domain.SyncDuties, error) {
+func (m *errorMockClient) GetSyncDuties(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error) {
 	return domain.SyncDuties{}, nil
 }
 
 func TestGetBlockReward_Errors(t *testing.T) {
 	zap.ReplaceGlobals(zap.NewNop())
 
-	brUC := usecase.NewBlockRewardUseCase(&errorMockClient{}, &dummyCacheBR{})
-	sdUC := usecase.NewSyncDutiesUseCase(&mockSD{}, &dummyCache{}) 
-	h := handler.NewHandler(brUC, sdUC)
+	brUC := usecase.NewBlockRewardUseCase(&mockResolver{}, &errorMockClient{}, &dummyCacheBR{}, &staticHead{}, nil, nil)
+	sdUC := usecase.NewSyncDutiesUseCase(usecase.NewProposerUseCase(&mockResolver{}, &mockPubkeys{}, &dummyCacheProposer{}, &staticHead{}), &mockSD{}, &dummyCache{}, &staticHead{}, nil, 0) 
+	h := handler.NewHandler(handler.UseCases{BlockReward: brUC, SyncDuties: sdUC})
 
 	r := chi.NewRouter()
 	h.Register(r)
@@ -113,6 +134,7 @@ func TestGetBlockReward_Errors(t *testing.T) {
 		wantErr    string
 	}{
 		{"/blockreward/abc", http.StatusBadRequest, "invalid slot"},
+		{"/blockreward/1?method=magic", http.StatusBadRequest, "invalid method"},
 		{"/blockreward/999999999999", http.StatusBadRequest, "slot in future"},
 		{"/blockreward/12345", http.StatusNotFound, "slot not found"},
 	}
@@ -138,7 +160,7 @@ func TestGetBlockReward_Errors(t *testing.T) {
 
 type errorSDClient struct{}
 
-func (m *errorSDClient) GetSyncDuties(ctx context.Context, slot uint64) (domain.SyncDuties, error) {
+func (m *errorSDClient) GetSyncDuties(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error) {
 	switch slot {
 	case 10:
 		return domain.SyncDuties{}, apierr.ErrSlotTooFarInFuture
@@ -147,16 +169,16 @@ func (m *errorSDClient) GetSyncDuties(ctx context.Context, slot uint64) (domain.
 	}
 	return domain.SyncDuties{}, stdErr.New("boom interno")
 }
-func (m *errorSDClient) GetBlockReward(ctx context.Context, slot uint64) (domain.BlockReward, error) {
+func (m *errorSDClient) GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error) {
 	return domain.BlockReward{}, nil
 }
 
 func TestGetSyncDuties_Errors(t *testing.T) {
 	zap.ReplaceGlobals(zap.NewNop())
 
-	brUC := usecase.NewBlockRewardUseCase(&mockBR{}, &dummyCacheBR{}) 
-	sdUC := usecase.NewSyncDutiesUseCase(&errorSDClient{}, &dummyCache{})
-	h := handler.NewHandler(brUC, sdUC)
+	brUC := usecase.NewBlockRewardUseCase(&mockResolver{}, &mockBR{}, &dummyCacheBR{}, &staticHead{}, nil, nil) 
+	sdUC := usecase.NewSyncDutiesUseCase(usecase.NewProposerUseCase(&mockResolver{}, &mockPubkeys{}, &dummyCacheProposer{}, &staticHead{}), &errorSDClient{}, &dummyCache{}, &staticHead{}, nil, 0)
+	h := handler.NewHandler(handler.UseCases{BlockReward: brUC, SyncDuties: sdUC})
 
 	r := chi.NewRouter()
 	h.Register(r)
@@ -187,4 +209,3 @@ func TestGetSyncDuties_Errors(t *testing.T) {
 		}
 	}
 }
-
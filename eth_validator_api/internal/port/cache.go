@@ -4,11 +4,36 @@ import "eth_validator_api/internal/domain"
 
 
 type SyncDutiesCache interface {
-    Add(slot uint64, duties domain.SyncDuties)
-    Get(slot uint64) (domain.SyncDuties, bool)
+    Add(period uint64, duties domain.SyncDuties)
+    Get(period uint64) (domain.SyncDuties, bool)
 }
 
 type BlockRewardCache interface {
-    Add(slot uint64, reward domain.BlockReward)
-    Get(slot uint64) (domain.BlockReward, bool)
+    Add(slot uint64, method domain.RewardMethod, reward domain.BlockReward)
+    Get(slot uint64, method domain.RewardMethod) (domain.BlockReward, bool)
+}
+
+type BlockFeesCache interface {
+    Add(slot uint64, fees domain.BlockFees)
+    Get(slot uint64) (domain.BlockFees, bool)
+}
+
+type ConsensusRewardCache interface {
+    Add(slot uint64, reward domain.ConsensusBlockReward)
+    Get(slot uint64) (domain.ConsensusBlockReward, bool)
+}
+
+type AttestationRewardsCache interface {
+    Add(epoch uint64, validators []string, rewards domain.AttestationRewards)
+    Get(epoch uint64, validators []string) (domain.AttestationRewards, bool)
+}
+
+type SyncCommitteeRewardsCache interface {
+    Add(slot uint64, rewards domain.SyncCommitteeRewards)
+    Get(slot uint64) (domain.SyncCommitteeRewards, bool)
+}
+
+type ProposerCache interface {
+    Add(slot uint64, proposer domain.ProposerIdentity)
+    Get(slot uint64) (domain.ProposerIdentity, bool)
 }
\ No newline at end of file
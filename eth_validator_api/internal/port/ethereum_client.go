@@ -2,12 +2,54 @@ package port
 
 import (
     "context"
+    "time"
+
     "eth_validator_api/internal/domain"
 )
 
 type BlockRewardClient interface {
-    GetBlockReward(ctx context.Context, slot uint64) (domain.BlockReward, error)
+    GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error)
+}
+type BlockFeesClient interface {
+    GetBlockFees(ctx context.Context, block domain.SlotBlock) (domain.BlockFees, error)
+}
+type ConsensusRewardsClient interface {
+    GetBlockRewards(ctx context.Context, slot uint64) (domain.ConsensusBlockReward, error)
+}
+type AttestationRewardsClient interface {
+    GetAttestationRewards(ctx context.Context, epoch uint64, validators []string) (domain.AttestationRewards, error)
+}
+type SyncCommitteeRewardsClient interface {
+    GetSyncCommitteeRewards(ctx context.Context, slot uint64) (domain.SyncCommitteeRewards, error)
+}
+type ValidatorIndexResolver interface {
+    ValidatorIndices(ctx context.Context, slot uint64, ids []string) ([]uint64, error)
+}
+type ValidatorPubkeyResolver interface {
+    ValidatorPubkey(ctx context.Context, index uint64) (string, error)
+}
+type ValidatorStatusClient interface {
+    GetValidatorStatus(ctx context.Context, state, id string) (domain.ValidatorStatus, error)
+}
+type ValidatorRegistry interface {
+    Pubkey(index uint64) (string, bool)
+    Index(pubkey string) (uint64, bool)
 }
 type SyncDutiesClient interface {
-    GetSyncDuties(ctx context.Context, slot uint64) (domain.SyncDuties, error)
+    GetSyncDuties(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error)
+}
+type GenesisClient interface {
+    GenesisTime(ctx context.Context) (time.Time, error)
+}
+type SlotResolver interface {
+    ResolveSlot(ctx context.Context, slot uint64) (domain.SlotBlock, error)
+}
+type RelayClient interface {
+    GetDeliveredPayloads(ctx context.Context, slot uint64) ([]domain.RelayDelivery, error)
+}
+type HeadTracker interface {
+    Head() (domain.ChainHead, bool)
+}
+type BuilderRegistry interface {
+    Identify(extraData []byte, coinbase string) string
 }
\ No newline at end of file
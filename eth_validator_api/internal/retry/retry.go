@@ -2,17 +2,38 @@ package retry
 
 import (
     "context"
+    "errors"
     "time"
 )
 
 type Operation func() error
 
+type permanentError struct {
+    err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error another attempt can't fix. Do returns it at once,
+// unwrapped.
+func Permanent(err error) error {
+    if err == nil {
+        return nil
+    }
+    return &permanentError{err: err}
+}
+
 func Do(ctx context.Context, attempts int, baseDelay time.Duration, op Operation) error {
     delay := baseDelay
 	var lastErr error
 
     for i := 0; i < attempts; i++ {
         if err := op(); err != nil {
+            var permanent *permanentError
+            if errors.As(err, &permanent) {
+                return permanent.err
+            }
 			lastErr = err
 			
             if ctx.Err() != nil {
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+    "context"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+const slotsPerEpoch = 32
+
+type AttestationRewardsUseCase struct {
+    client port.AttestationRewardsClient
+    cache  port.AttestationRewardsCache
+    head   port.HeadTracker
+}
+
+func NewAttestationRewardsUseCase(
+    client port.AttestationRewardsClient,
+    cache port.AttestationRewardsCache,
+    head port.HeadTracker,
+) *AttestationRewardsUseCase {
+    return &AttestationRewardsUseCase{client: client, cache: cache, head: head}
+}
+
+func (uc *AttestationRewardsUseCase) Execute(
+    ctx context.Context,
+    epoch uint64,
+    validators []string,
+) (domain.AttestationRewards, error) {
+    ids, err := normalizeValidatorIDs(validators)
+    if err != nil {
+        return domain.AttestationRewards{}, err
+    }
+    if len(ids) == 0 {
+        return domain.AttestationRewards{}, apierr.ErrNoValidators
+    }
+
+    if v, ok := uc.cache.Get(epoch, ids); ok {
+        return v, nil
+    }
+    // Attestations for an epoch can still be included during the next one,
+    // so rewards are only known once the head is past both.
+    if err := checkSlotReached(uc.head, (epoch+2)*slotsPerEpoch-1, apierr.ErrEpochNotComplete); err != nil {
+        return domain.AttestationRewards{}, err
+    }
+
+    rewards, err := uc.client.GetAttestationRewards(ctx, epoch, ids)
+    if err != nil {
+        return domain.AttestationRewards{}, err
+    }
+
+    uc.cache.Add(epoch, ids, rewards)
+    return rewards, nil
+}
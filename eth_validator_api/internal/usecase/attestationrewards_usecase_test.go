@@ -0,0 +1,84 @@
+package usecase_test
+
+import (
+    "context"
+    "reflect"
+    "strings"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/usecase"
+)
+
+type recordingARClient struct {
+    calls int
+    got   []string
+}
+
+func (m *recordingARClient) GetAttestationRewards(ctx context.Context, epoch uint64, validators []string) (domain.AttestationRewards, error) {
+    m.calls++
+    m.got = validators
+    return domain.AttestationRewards{Epoch: epoch, TotalRewards: []domain.AttestationReward{{ValidatorIndex: 1, Head: -10}}}, nil
+}
+
+type dummyCacheAR struct {
+    store map[string]domain.AttestationRewards
+}
+
+func (c *dummyCacheAR) Get(epoch uint64, validators []string) (domain.AttestationRewards, bool) {
+    r, ok := c.store[strings.Join(validators, ",")]
+    return r, ok
+}
+
+func (c *dummyCacheAR) Add(epoch uint64, validators []string, rewards domain.AttestationRewards) {
+    c.store[strings.Join(validators, ",")] = rewards
+}
+
+const testPubkey = "0xA63E0F5CC97436716D3F06D5A203D1599ED0C219DDA21005EDDB8D24C38FCB139AEF505307E91F4E13798907C44A0B47"
+
+func TestAttestationRewardsUseCase_NormalizesValidatorSet(t *testing.T) {
+    client := &recordingARClient{}
+    uc := usecase.NewAttestationRewardsUseCase(client, &dummyCacheAR{store: map[string]domain.AttestationRewards{}}, farHead)
+
+    if _, err := uc.Execute(context.Background(), 10, []string{"2", testPubkey, " 1", "2"}); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    want := []string{strings.ToLower(testPubkey), "1", "2"}
+    if !reflect.DeepEqual(client.got, want) {
+        t.Errorf("esperaba %v, got %v", want, client.got)
+    }
+    if _, err := uc.Execute(context.Background(), 10, []string{"1", strings.ToLower(testPubkey), "2"}); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if client.calls != 1 {
+        t.Errorf("esperaba el mismo conjunto servido desde caché, llamadas=%d", client.calls)
+    }
+}
+
+func TestAttestationRewardsUseCase_InvalidInput(t *testing.T) {
+    uc := usecase.NewAttestationRewardsUseCase(&recordingARClient{}, &dummyCacheAR{store: map[string]domain.AttestationRewards{}}, farHead)
+
+    if _, err := uc.Execute(context.Background(), 10, []string{"abc"}); err != apierr.ErrInvalidValidatorID {
+        t.Errorf("esperaba ErrInvalidValidatorID, got %v", err)
+    }
+    if _, err := uc.Execute(context.Background(), 10, []string{"0x1234"}); err != apierr.ErrInvalidValidatorID {
+        t.Errorf("esperaba ErrInvalidValidatorID para pubkey corta, got %v", err)
+    }
+    if _, err := uc.Execute(context.Background(), 10, nil); err != apierr.ErrNoValidators {
+        t.Errorf("esperaba ErrNoValidators, got %v", err)
+    }
+}
+
+func TestAttestationRewardsUseCase_EpochNotComplete(t *testing.T) {
+    client := &recordingARClient{}
+    // Head at the last slot of epoch 11: epoch 10 is complete, epoch 11 isn't.
+    uc := usecase.NewAttestationRewardsUseCase(client, &dummyCacheAR{store: map[string]domain.AttestationRewards{}}, staticHead{slot: 12*32 - 1})
+
+    if _, err := uc.Execute(context.Background(), 11, []string{"1"}); err != apierr.ErrEpochNotComplete {
+        t.Fatalf("esperaba ErrEpochNotComplete, got %v", err)
+    }
+    if _, err := uc.Execute(context.Background(), 10, []string{"1"}); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+}
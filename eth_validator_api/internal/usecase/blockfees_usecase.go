@@ -0,0 +1,57 @@
+package usecase
+
+import (
+    "context"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+type BlockFeesUseCase struct {
+    resolver port.SlotResolver
+    client   port.BlockFeesClient
+    cache    port.BlockFeesCache
+    head     port.HeadTracker
+}
+
+func NewBlockFeesUseCase(
+    resolver port.SlotResolver,
+    client port.BlockFeesClient,
+    cache port.BlockFeesCache,
+    head port.HeadTracker,
+) *BlockFeesUseCase {
+    return &BlockFeesUseCase{resolver: resolver, client: client, cache: cache, head: head}
+}
+
+func (uc *BlockFeesUseCase) Execute(ctx context.Context, slot uint64) (domain.BlockFees, error) {
+    if v, ok := uc.cache.Get(slot); ok {
+        return v, nil
+    }
+    if err := checkSlotReached(uc.head, slot, apierr.ErrSlotInFuture); err != nil {
+        return domain.BlockFees{}, err
+    }
+
+    block, err := uc.resolver.ResolveSlot(ctx, slot)
+    if err != nil {
+        return domain.BlockFees{}, err
+    }
+    if block.Missed {
+        missed := domain.BlockFees{
+            Finality:      domain.Finality{Finalized: slotFinalized(uc.head, slot)},
+            Slot:          slot,
+            ProposerIndex: block.ProposerIndex,
+            Status:        domain.SlotStatusMissed,
+        }
+        uc.cache.Add(slot, missed)
+        return missed, nil
+    }
+
+    fees, err := uc.client.GetBlockFees(ctx, block)
+    if err != nil {
+        return domain.BlockFees{}, err
+    }
+
+    uc.cache.Add(slot, fees)
+    return fees, nil
+}
@@ -0,0 +1,78 @@
+package usecase_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/usecase"
+)
+
+type mockFeesClient struct {
+    calls int
+    err   error
+}
+
+func (m *mockFeesClient) GetBlockFees(ctx context.Context, block domain.SlotBlock) (domain.BlockFees, error) {
+    m.calls++
+    if m.err != nil {
+        return domain.BlockFees{}, m.err
+    }
+    return domain.BlockFees{Slot: block.Slot, BlockNumber: block.BlockNumber, Status: domain.SlotStatusProposed, BurntFeesWei: "42"}, nil
+}
+
+type dummyCacheFees struct {
+    store map[uint64]domain.BlockFees
+}
+
+func (c *dummyCacheFees) Get(slot uint64) (domain.BlockFees, bool) {
+    f, ok := c.store[slot]
+    return f, ok
+}
+
+func (c *dummyCacheFees) Add(slot uint64, fees domain.BlockFees) {
+    c.store[slot] = fees
+}
+
+func TestBlockFeesUseCase_ResolvesAndCaches(t *testing.T) {
+    client := &mockFeesClient{}
+    cache := &dummyCacheFees{store: map[uint64]domain.BlockFees{}}
+    uc := usecase.NewBlockFeesUseCase(&mockResolver{blockNumbers: map[uint64]uint64{10: 100}}, client, cache, farHead)
+
+    for i := 0; i < 2; i++ {
+        res, err := uc.Execute(context.Background(), 10)
+        if err != nil {
+            t.Fatalf("esperaba sin error, got %v", err)
+        }
+        if res.BlockNumber != 100 || res.BurntFeesWei != "42" {
+            t.Errorf("resultado inesperado: %+v", res)
+        }
+    }
+    if client.calls != 1 {
+        t.Errorf("esperaba una sola llamada al cliente, got %d", client.calls)
+    }
+}
+
+func TestBlockFeesUseCase_MissedSlot(t *testing.T) {
+    client := &mockFeesClient{err: errors.New("no debe llamarse")}
+    cache := &dummyCacheFees{store: map[uint64]domain.BlockFees{}}
+    uc := usecase.NewBlockFeesUseCase(&mockResolver{missed: map[uint64]uint64{11: 7}}, client, cache, farHead)
+
+    res, err := uc.Execute(context.Background(), 11)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Status != domain.SlotStatusMissed || res.ProposerIndex != 7 || client.calls != 0 {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+}
+
+func TestBlockFeesUseCase_SlotAfterHead(t *testing.T) {
+    cache := &dummyCacheFees{store: map[uint64]domain.BlockFees{}}
+    uc := usecase.NewBlockFeesUseCase(&mockResolver{}, &mockFeesClient{}, cache, staticHead{slot: 5})
+    if _, err := uc.Execute(context.Background(), 6); err != apierr.ErrSlotInFuture {
+        t.Fatalf("esperaba ErrSlotInFuture, got %v", err)
+    }
+}
@@ -2,36 +2,151 @@ package usecase
 
 import (
     "context"
+    "math/big"
+    "strings"
+
+    "go.uber.org/zap"
+
+    apierr "eth_validator_api/internal/errors"
     "eth_validator_api/internal/port"
     "eth_validator_api/internal/domain"
 )
 
 type BlockRewardUseCase struct {
-    client port.BlockRewardClient
-    cache  port.BlockRewardCache
+    resolver  port.SlotResolver
+    client    port.BlockRewardClient
+    cache     port.BlockRewardCache
+    head      port.HeadTracker
+    relays    port.RelayClient
+    proposers *ProposerUseCase
 }
 
 func NewBlockRewardUseCase(
+    resolver port.SlotResolver,
     client port.BlockRewardClient,
     cache port.BlockRewardCache,
+    head port.HeadTracker,
+    relays port.RelayClient,
+    proposers *ProposerUseCase,
 ) *BlockRewardUseCase {
-    return &BlockRewardUseCase{client: client, cache: cache}
+    return &BlockRewardUseCase{resolver: resolver, client: client, cache: cache, head: head, relays: relays, proposers: proposers}
 }
 
 func (uc *BlockRewardUseCase) Execute(
     ctx context.Context,
     slot uint64,
+    method domain.RewardMethod,
 ) (domain.BlockReward, error) {
     
-    if v, ok := uc.cache.Get(slot); ok {
+    if v, ok := uc.cache.Get(slot, method); ok {
         return v, nil
     }
+    if err := checkSlotReached(uc.head, slot, apierr.ErrSlotInFuture); err != nil {
+        return domain.BlockReward{}, err
+    }
+
+    block, err := uc.resolver.ResolveSlot(ctx, slot)
+    if err != nil {
+        return domain.BlockReward{}, err
+    }
+    if block.Missed {
+        missed := domain.BlockReward{
+            Finality:      domain.Finality{Finalized: slotFinalized(uc.head, slot)},
+            Slot:          slot,
+            ProposerIndex: block.ProposerIndex,
+            Status:        domain.SlotStatusMissed,
+        }
+        missed.SetReward(new(big.Int))
+        if err := uc.addProposer(ctx, block, &missed); err != nil {
+            return domain.BlockReward{}, err
+        }
+        uc.cache.Add(slot, method, missed)
+        return missed, nil
+    }
    
-    reward, err := uc.client.GetBlockReward(ctx, slot)
+    reward, err := uc.client.GetBlockReward(ctx, block, method)
     if err != nil {
         return domain.BlockReward{}, err
     }
+    relaysComplete := true
+    if uc.relays != nil {
+        relaysComplete = uc.addRelayDeliveries(ctx, &reward)
+    }
+    if err := uc.addProposer(ctx, block, &reward); err != nil {
+        return domain.BlockReward{}, err
+    }
 
-    uc.cache.Add(slot, reward)
+    // Finalized entries never expire, so a reward missing a relay that
+    // didn't answer is served but not cached; the next request asks again.
+    if relaysComplete {
+        uc.cache.Add(slot, method, reward)
+    }
     return reward, nil
 }
+
+func (uc *BlockRewardUseCase) addProposer(ctx context.Context, block domain.SlotBlock, reward *domain.BlockReward) error {
+    if uc.proposers == nil {
+        return nil
+    }
+    proposer, err := uc.proposers.fromBlock(ctx, block)
+    if err != nil {
+        return err
+    }
+    reward.ProposerPubkey = proposer.Pubkey
+    reward.Graffiti = proposer.Graffiti
+    if reward.FeeRecipient == "" {
+        reward.FeeRecipient = proposer.FeeRecipient
+    }
+    return nil
+}
+
+// addRelayDeliveries attaches the relays that delivered this block's payload
+// and reports whether every relay answered. Relay data is informational, so a
+// relay outage doesn't fail the request.
+func (uc *BlockRewardUseCase) addRelayDeliveries(ctx context.Context, reward *domain.BlockReward) bool {
+    deliveries, err := uc.relays.GetDeliveredPayloads(ctx, reward.Slot)
+    if err != nil {
+        zap.L().Warn("relay lookup failed", zap.Uint64("slot", reward.Slot), zap.Error(err))
+    }
+    for _, d := range deliveries {
+        if reward.BlockHash != "" && !strings.EqualFold(d.BlockHash, reward.BlockHash) {
+            continue
+        }
+        reward.Relays = append(reward.Relays, d)
+    }
+    if len(reward.Relays) > 0 {
+        reward.Status = "mev"
+        checkRelayFeeRecipient(reward)
+    }
+    return err == nil
+}
+
+// checkRelayFeeRecipient compares the fee recipient found on chain with the
+// one the relay delivered the payload for, which is the address the proposer
+// registered. When they differ the relay wins: a payment found on chain went
+// elsewhere and is dropped, and without one the coinbase is the builder.
+func checkRelayFeeRecipient(reward *domain.BlockReward) {
+    feeRecipient := reward.Relays[0].ProposerFeeRecipient
+    if feeRecipient == "" || strings.EqualFold(feeRecipient, reward.FeeRecipient) {
+        return
+    }
+    zap.L().Warn("fee recipient differs from the relay's",
+        zap.Uint64("slot", reward.Slot),
+        zap.String("on_chain", reward.FeeRecipient),
+        zap.String("relay", feeRecipient))
+
+    if reward.ProposerPaymentTx == "" {
+        reward.BuilderAddress = reward.FeeRecipient
+    } else if reward.Breakdown != nil {
+        // The transfer stays in the breakdown as a plain transfer.
+        payment, _ := new(big.Int).SetString(reward.Breakdown.ProposerPaymentWei, 10)
+        direct, _ := new(big.Int).SetString(reward.Breakdown.DirectTransfersWei, 10)
+        if payment != nil && direct != nil {
+            reward.Breakdown.DirectTransfersWei = direct.Add(direct, payment).String()
+            reward.Breakdown.ProposerPaymentWei = "0"
+        }
+    }
+    reward.FeeRecipient = feeRecipient
+    reward.ProposerPaymentWei = ""
+    reward.ProposerPaymentTx = ""
+}
@@ -3,8 +3,10 @@ package usecase_test
 import (
     "context"
     "errors"
+    "strings"
     "testing"
 
+    apierr "eth_validator_api/internal/errors"
     "eth_validator_api/internal/domain"
     "eth_validator_api/internal/usecase"
 )
@@ -14,50 +16,94 @@ type mockBRClient struct {
     err    error
 }
 
+type brKey struct {
+    slot   uint64
+    method domain.RewardMethod
+}
+
 type dummyCacheBR struct {
-    store map[uint64]domain.BlockReward
+    store map[brKey]domain.BlockReward
 }
 
 func newdummyCacheBR() *dummyCacheBR {
-    return &dummyCacheBR{store: make(map[uint64]domain.BlockReward)}
+    return &dummyCacheBR{store: make(map[brKey]domain.BlockReward)}
 }
 
-func (c *dummyCacheBR) Get(slot uint64) (domain.BlockReward, bool) {
-    d, ok := c.store[slot]
+func (c *dummyCacheBR) Get(slot uint64, method domain.RewardMethod) (domain.BlockReward, bool) {
+    d, ok := c.store[brKey{slot, method}]
     return d, ok
 }
 
-func (c *dummyCacheBR) Add(slot uint64, reward domain.BlockReward) {
-    c.store[slot] = reward
+func (c *dummyCacheBR) Add(slot uint64, method domain.RewardMethod, reward domain.BlockReward) {
+    c.store[brKey{slot, method}] = reward
 }
 
-func (m *mockBRClient) GetBlockReward(ctx context.Context, slot uint64) (domain.BlockReward, error) {
+func (m *mockBRClient) GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error) {
     return m.result, m.err
 }
 
+type mockResolver struct {
+    blockNumbers map[uint64]uint64
+    missed       map[uint64]uint64
+    err          error
+}
+
+func (m *mockResolver) ResolveSlot(ctx context.Context, slot uint64) (domain.SlotBlock, error) {
+    if m.err != nil {
+        return domain.SlotBlock{}, m.err
+    }
+    if proposer, ok := m.missed[slot]; ok {
+        return domain.SlotBlock{Slot: slot, Missed: true, ProposerIndex: proposer}, nil
+    }
+    return domain.SlotBlock{Slot: slot, BlockNumber: m.blockNumbers[slot]}, nil
+}
+
+type staticHead struct {
+    slot      uint64
+    finalized uint64
+    unsynced  bool
+}
+
+func (h staticHead) Head() (domain.ChainHead, bool) {
+    return domain.ChainHead{HeadSlot: h.slot, FinalizedSlot: h.finalized}, !h.unsynced
+}
+
+var farHead = staticHead{slot: 1 << 40}
+
+type recordingBRClient struct {
+    got       domain.SlotBlock
+    gotMethod domain.RewardMethod
+}
+
+func (m *recordingBRClient) GetBlockReward(ctx context.Context, block domain.SlotBlock, method domain.RewardMethod) (domain.BlockReward, error) {
+    m.got = block
+    m.gotMethod = method
+    return domain.BlockReward{Slot: block.Slot, BlockNumber: block.BlockNumber, Status: "vanilla"}, nil
+}
+
 func TestBlockRewardUseCase_GenesisSlot(t *testing.T) {
     cache := newdummyCacheBR()
-    uc := usecase.NewBlockRewardUseCase(&mockBRClient{
-        result: domain.BlockReward{Status: "vanilla", Reward: 0},
+    uc := usecase.NewBlockRewardUseCase(&mockResolver{}, &mockBRClient{
+        result: domain.BlockReward{Status: "vanilla", RewardWei: "0"},
         err:  nil,
-    }, cache)
-    res, err := uc.Execute(context.Background(), 0)
+    }, cache, farHead, nil, nil)
+    res, err := uc.Execute(context.Background(), 0, domain.RewardMethodBalance)
     if err != nil {
         t.Fatalf("esperaba sin error para slot génesis, got %v", err)
     }
     br := res
-    if br.Reward != 0 || br.Status != "vanilla" {
+    if br.RewardWei != "0" || br.Status != "vanilla" {
         t.Errorf("resultado inesperado: %+v", br)
     }
 }
 
 func TestBlockRewardUseCase_SlotNotFound(t *testing.T) {
     cache := newdummyCacheBR()
-    uc := usecase.NewBlockRewardUseCase(&mockBRClient{
+    uc := usecase.NewBlockRewardUseCase(&mockResolver{}, &mockBRClient{
         result: domain.BlockReward{},
         err:    errors.New("slot not found"),
-    }, cache)
-    _, err := uc.Execute(context.Background(), 123)
+    }, cache, farHead, nil, nil)
+    _, err := uc.Execute(context.Background(), 123, domain.RewardMethodBalance)
     if err == nil {
         t.Fatal("esperaba error para slot inexistente")
     }
@@ -65,14 +111,280 @@ func TestBlockRewardUseCase_SlotNotFound(t *testing.T) {
 
 func TestBlockRewardUseCase_SlotInFuture(t *testing.T) {
     cache := newdummyCacheBR()
-    uc := usecase.NewBlockRewardUseCase(&mockBRClient{
+    uc := usecase.NewBlockRewardUseCase(&mockResolver{}, &mockBRClient{
         result: domain.BlockReward{},
         err:    errors.New("slot in future"),
-    }, cache)
-    _, err := uc.Execute(context.Background(), 999999)
+    }, cache, farHead, nil, nil)
+    _, err := uc.Execute(context.Background(), 999999, domain.RewardMethodBalance)
     if err == nil {
         t.Fatal("esperaba error para slot futuro")
     }
 }
 
+func TestBlockRewardUseCase_ResolvesSlotToBlockNumber(t *testing.T) {
+    cache := newdummyCacheBR()
+    client := &recordingBRClient{}
+    resolver := &mockResolver{blockNumbers: map[uint64]uint64{11_000_000: 21_800_000}}
+    uc := usecase.NewBlockRewardUseCase(resolver, client, cache, farHead, nil, nil)
+
+    res, err := uc.Execute(context.Background(), 11_000_000, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if client.got.BlockNumber != 21_800_000 {
+        t.Errorf("esperaba bloque 21800000, got %d", client.got.BlockNumber)
+    }
+    if res.Slot != 11_000_000 || res.BlockNumber != 21_800_000 {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+}
+
+func TestBlockRewardUseCase_ResolverError(t *testing.T) {
+    cache := newdummyCacheBR()
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{err: apierr.ErrSlotNotFound},
+        &mockBRClient{err: errors.New("no debe llamarse")},
+        cache,
+        farHead,
+        nil,
+        nil,
+    )
+    _, err := uc.Execute(context.Background(), 5, domain.RewardMethodBalance)
+    if err != apierr.ErrSlotNotFound {
+        t.Fatalf("esperaba ErrSlotNotFound, got %v", err)
+    }
+    if _, found := cache.Get(5, domain.RewardMethodBalance); found {
+        t.Error("no esperaba que se cachease tras un error")
+    }
+}
+
+func TestBlockRewardUseCase_MissedSlot(t *testing.T) {
+    cache := newdummyCacheBR()
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{missed: map[uint64]uint64{77: 4321}},
+        &mockBRClient{err: errors.New("no debe llamarse")},
+        cache,
+        farHead,
+        nil,
+        nil,
+    )
+    res, err := uc.Execute(context.Background(), 77, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Status != domain.SlotStatusMissed || res.ProposerIndex != 4321 || res.RewardWei != "0" {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+    if res.Finalized {
+        t.Error("no esperaba un slot perdido finalizado por encima del checkpoint")
+    }
+    if _, found := cache.Get(77, domain.RewardMethodBalance); !found {
+        t.Error("esperaba que un slot perdido se guardase en caché")
+    }
+}
+
+func TestBlockRewardUseCase_SlotAfterHead(t *testing.T) {
+    cache := newdummyCacheBR()
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{err: errors.New("no debe llamarse")},
+        &mockBRClient{err: errors.New("no debe llamarse")},
+        cache,
+        staticHead{slot: 100},
+        nil,
+        nil,
+    )
+    _, err := uc.Execute(context.Background(), 101, domain.RewardMethodBalance)
+    if err != apierr.ErrSlotInFuture {
+        t.Fatalf("esperaba ErrSlotInFuture, got %v", err)
+    }
+}
 
+func TestBlockRewardUseCase_HeadUnavailable(t *testing.T) {
+    cache := newdummyCacheBR()
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{},
+        &mockBRClient{},
+        cache,
+        staticHead{unsynced: true},
+        nil,
+        nil,
+    )
+    _, err := uc.Execute(context.Background(), 1, domain.RewardMethodBalance)
+    if err != apierr.ErrHeadUnavailable {
+        t.Fatalf("esperaba ErrHeadUnavailable, got %v", err)
+    }
+}
+
+func TestBlockRewardUseCase_MissedSlotFinalized(t *testing.T) {
+    cache := newdummyCacheBR()
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{missed: map[uint64]uint64{77: 4321}},
+        &mockBRClient{},
+        cache,
+        staticHead{slot: 200, finalized: 128},
+        nil,
+        nil,
+    )
+    res, err := uc.Execute(context.Background(), 77, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if !res.Finalized {
+        t.Errorf("esperaba slot perdido finalizado: %+v", res)
+    }
+}
+
+func TestBlockRewardUseCase_CachesPerMethod(t *testing.T) {
+    cache := newdummyCacheBR()
+    client := &recordingBRClient{}
+    uc := usecase.NewBlockRewardUseCase(&mockResolver{blockNumbers: map[uint64]uint64{9: 90}}, client, cache, farHead, nil, nil)
+
+    if _, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if _, err := uc.Execute(context.Background(), 9, domain.RewardMethodReceipts); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if client.gotMethod != domain.RewardMethodReceipts {
+        t.Errorf("esperaba método receipts en el cliente, got %q", client.gotMethod)
+    }
+    if _, found := cache.Get(9, domain.RewardMethodReceipts); !found {
+        t.Error("esperaba una entrada de caché por método")
+    }
+}
+
+type mockRelays struct {
+    deliveries []domain.RelayDelivery
+    err        error
+}
+
+func (m *mockRelays) GetDeliveredPayloads(ctx context.Context, slot uint64) ([]domain.RelayDelivery, error) {
+    return m.deliveries, m.err
+}
+
+func TestBlockRewardUseCase_RelayDeliveries(t *testing.T) {
+    cache := newdummyCacheBR()
+    relays := &mockRelays{deliveries: []domain.RelayDelivery{
+        {Relay: "https://relay-a", BlockHash: "0xABC", ValueWei: "1000"},
+        {Relay: "https://relay-b", BlockHash: "0xdef", ValueWei: "999"},
+    }}
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{blockNumbers: map[uint64]uint64{9: 90}},
+        &mockBRClient{result: domain.BlockReward{Slot: 9, BlockHash: "0xabc", Status: "vanilla"}},
+        cache,
+        farHead,
+        relays,
+        nil,
+    )
+    res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Status != "mev" || len(res.Relays) != 1 || res.Relays[0].Relay != "https://relay-a" {
+        t.Errorf("esperaba solo el relay que entregó el bloque: %+v", res)
+    }
+}
+
+func TestBlockRewardUseCase_RelayErrorIgnored(t *testing.T) {
+    cache := newdummyCacheBR()
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{blockNumbers: map[uint64]uint64{9: 90}},
+        &mockBRClient{result: domain.BlockReward{Slot: 9, BlockHash: "0xabc", Status: "vanilla"}},
+        cache,
+        farHead,
+        &mockRelays{err: errors.New("relays caídos")},
+        nil,
+    )
+    res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("un fallo de relay no debe fallar la petición, got %v", err)
+    }
+    if res.Status != "vanilla" || res.Relays != nil {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+    if _, found := cache.Get(9, domain.RewardMethodBalance); found {
+        t.Error("no esperaba cachear una recompensa sin datos de relays")
+    }
+}
+
+func TestBlockRewardUseCase_PartialRelayDeliveries(t *testing.T) {
+    cache := newdummyCacheBR()
+    uc := usecase.NewBlockRewardUseCase(
+        &mockResolver{blockNumbers: map[uint64]uint64{9: 90}},
+        &mockBRClient{result: domain.BlockReward{Slot: 9, BlockHash: "0xabc", Status: "vanilla"}},
+        cache,
+        farHead,
+        &mockRelays{
+            deliveries: []domain.RelayDelivery{{Relay: "https://relay-a", BlockHash: "0xabc", ValueWei: "1000"}},
+            err:        errors.New("relay-b caído"),
+        },
+        nil,
+    )
+    res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Status != "mev" || len(res.Relays) != 1 {
+        t.Errorf("esperaba las entregas de los relays que respondieron: %+v", res)
+    }
+    if _, found := cache.Get(9, domain.RewardMethodBalance); found {
+        t.Error("no esperaba cachear una consulta de relays incompleta")
+    }
+}
+
+
+func TestBlockRewardUseCase_RelayFeeRecipient(t *testing.T) {
+    delivery := domain.RelayDelivery{Relay: "https://relay-a", BlockHash: "0xabc", ProposerFeeRecipient: "0xFEE1", ValueWei: "1000"}
+    cases := []struct {
+        name        string
+        onChain     domain.BlockReward
+        wantBuilder string
+        wantTx      string
+    }{
+        {
+            name: "PaymentMatches",
+            onChain: domain.BlockReward{Slot: 9, BlockHash: "0xabc", Status: "mev", FeeRecipient: "0xfee1",
+                BuilderAddress: "0xB1", ProposerPaymentWei: "1000", ProposerPaymentTx: "0x02"},
+            wantBuilder: "0xB1",
+            wantTx:      "0x02",
+        },
+        {
+            // The coinbase's last transfer went somewhere else.
+            name: "PaymentMismatch",
+            onChain: domain.BlockReward{Slot: 9, BlockHash: "0xabc", Status: "mev", FeeRecipient: "0xAAAA",
+                BuilderAddress: "0xB1", ProposerPaymentWei: "5", ProposerPaymentTx: "0x02"},
+            wantBuilder: "0xB1",
+        },
+        {
+            // No payment on chain: the payload's fee recipient is the builder.
+            name:        "NoPayment",
+            onChain:     domain.BlockReward{Slot: 9, BlockHash: "0xabc", Status: "vanilla", FeeRecipient: "0xB1"},
+            wantBuilder: "0xB1",
+        },
+    }
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            uc := usecase.NewBlockRewardUseCase(
+                &mockResolver{blockNumbers: map[uint64]uint64{9: 90}},
+                &mockBRClient{result: tc.onChain},
+                newdummyCacheBR(),
+                farHead,
+                &mockRelays{deliveries: []domain.RelayDelivery{delivery}},
+                nil,
+            )
+            res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+            if err != nil {
+                t.Fatalf("esperaba sin error, got %v", err)
+            }
+            if !strings.EqualFold(res.FeeRecipient, "0xFEE1") {
+                t.Errorf("esperaba el fee recipient del relay, got %q", res.FeeRecipient)
+            }
+            if res.BuilderAddress != tc.wantBuilder || res.ProposerPaymentTx != tc.wantTx {
+                t.Errorf("builder=%q pago=%q, esperaba %q y %q", res.BuilderAddress, res.ProposerPaymentTx, tc.wantBuilder, tc.wantTx)
+            }
+            if tc.wantTx == "" && res.ProposerPaymentWei != "" {
+                t.Errorf("no esperaba pago al proposer, got %s", res.ProposerPaymentWei)
+            }
+        })
+    }
+}
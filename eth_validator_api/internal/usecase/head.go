@@ -0,0 +1,22 @@
+package usecase
+
+import (
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+func checkSlotReached(head port.HeadTracker, slot uint64, futureErr error) error {
+    h, ok := head.Head()
+    if !ok {
+        return apierr.ErrHeadUnavailable
+    }
+    if slot > h.HeadSlot {
+        return futureErr
+    }
+    return nil
+}
+
+func slotFinalized(head port.HeadTracker, slot uint64) bool {
+    h, ok := head.Head()
+    return ok && slot <= h.FinalizedSlot
+}
\ No newline at end of file
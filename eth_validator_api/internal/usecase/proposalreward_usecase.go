@@ -0,0 +1,99 @@
+package usecase
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+
+    "eth_validator_api/internal/domain"
+    "eth_validator_api/internal/port"
+)
+
+// ProposalRewardUseCase adds the consensus layer block reward to the
+// execution layer reward computed by BlockRewardUseCase.
+type ProposalRewardUseCase struct {
+    blockRewards *BlockRewardUseCase
+    client       port.ConsensusRewardsClient
+    cache        port.ConsensusRewardCache
+}
+
+func NewProposalRewardUseCase(
+    blockRewards *BlockRewardUseCase,
+    client port.ConsensusRewardsClient,
+    cache port.ConsensusRewardCache,
+) *ProposalRewardUseCase {
+    return &ProposalRewardUseCase{blockRewards: blockRewards, client: client, cache: cache}
+}
+
+func (uc *ProposalRewardUseCase) Execute(
+    ctx context.Context,
+    slot uint64,
+    method domain.RewardMethod,
+) (domain.ProposalReward, error) {
+    el, err := uc.blockRewards.Execute(ctx, slot, method)
+    if err != nil {
+        return domain.ProposalReward{}, err
+    }
+
+    res := domain.ProposalReward{
+        Finality:      el.Finality,
+        Slot:          slot,
+        ProposerIndex: el.ProposerIndex,
+        Status:        el.Status,
+        Execution:     el,
+    }
+    if el.Status == domain.SlotStatusMissed {
+        res.ExecutionIncomeWei = "0"
+        res.ExecutionIncomeVerified = true
+        res.SetTotal(new(big.Int))
+        return res, nil
+    }
+
+    cl, ok := uc.cache.Get(slot)
+    if !ok {
+        cl, err = uc.client.GetBlockRewards(ctx, slot)
+        if err != nil {
+            return domain.ProposalReward{}, err
+        }
+        uc.cache.Add(slot, cl)
+    }
+    res.Consensus = &cl
+    res.Finalized = el.Finalized && cl.Finalized
+    res.ExecutionOptimistic = el.ExecutionOptimistic || cl.ExecutionOptimistic
+
+    income, verified, err := executionIncome(el)
+    if err != nil {
+        return domain.ProposalReward{}, err
+    }
+    res.ExecutionIncomeWei = income.String()
+    res.ExecutionIncomeVerified = verified
+
+    total := new(big.Int).Mul(new(big.Int).SetUint64(cl.Total), big.NewInt(1e9))
+    res.SetTotal(total.Add(total, income))
+    return res, nil
+}
+
+// executionIncome is what the fee recipient got. It is the builder's
+// payment for MEV-boost blocks and the coinbase reward when the fee recipient
+// is the coinbase. A block is builder-built when a builder address, a relay
+// delivery or a known builder was found. If no payment was found in such a
+// block, the coinbase reward is the builder's profit, so the relay's claimed
+// value is used instead, or 0 without one, and the figure is reported
+// unverified.
+func executionIncome(el domain.BlockReward) (*big.Int, bool, error) {
+    wei, verified := el.RewardWei, true
+    switch {
+    case el.ProposerPaymentWei != "":
+        wei = el.ProposerPaymentWei
+    case el.BuilderAddress != "" || len(el.Relays) > 0 || el.Builder != "":
+        wei, verified = "0", false
+        if len(el.Relays) > 0 {
+            wei = el.Relays[0].ValueWei
+        }
+    }
+    income, ok := new(big.Int).SetString(wei, 10)
+    if !ok {
+        return nil, false, fmt.Errorf("invalid execution reward %q", wei)
+    }
+    return income, verified, nil
+}
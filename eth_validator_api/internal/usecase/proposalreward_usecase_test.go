@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    "eth_validator_api/internal/usecase"
+)
+
+type mockCLRewards struct {
+    reward domain.ConsensusBlockReward
+    err    error
+    calls  int
+}
+
+func (m *mockCLRewards) GetBlockRewards(ctx context.Context, slot uint64) (domain.ConsensusBlockReward, error) {
+    m.calls++
+    return m.reward, m.err
+}
+
+type dummyCacheCL struct {
+    store map[uint64]domain.ConsensusBlockReward
+}
+
+func (c *dummyCacheCL) Get(slot uint64) (domain.ConsensusBlockReward, bool) {
+    r, ok := c.store[slot]
+    return r, ok
+}
+
+func (c *dummyCacheCL) Add(slot uint64, reward domain.ConsensusBlockReward) {
+    c.store[slot] = reward
+}
+
+func newProposalRewardUseCase(el domain.BlockReward, cl *mockCLRewards) *usecase.ProposalRewardUseCase {
+    br := usecase.NewBlockRewardUseCase(
+        &mockResolver{blockNumbers: map[uint64]uint64{9: 90}, missed: map[uint64]uint64{10: 5}},
+        &mockBRClient{result: el},
+        newdummyCacheBR(),
+        farHead,
+        nil,
+        nil,
+    )
+    return usecase.NewProposalRewardUseCase(br, cl, &dummyCacheCL{store: map[uint64]domain.ConsensusBlockReward{}})
+}
+
+func TestProposalRewardUseCase_AddsConsensusAndExecution(t *testing.T) {
+    cl := &mockCLRewards{reward: domain.ConsensusBlockReward{Finality: domain.Finality{Finalized: true}, Total: 40_000_000}}
+    uc := newProposalRewardUseCase(domain.BlockReward{
+        Finality:  domain.Finality{Finalized: false},
+        Slot:      9,
+        Status:    "vanilla",
+        RewardWei: "12500000000000001",
+    }, cl)
+
+    res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.TotalWei != "52500000000000001" || res.ExecutionIncomeWei != "12500000000000001" || !res.ExecutionIncomeVerified {
+        t.Errorf("total inesperado: %+v", res)
+    }
+    if res.Finalized {
+        t.Error("no esperaba finalizado si la parte de ejecución no lo está")
+    }
+    if _, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance); err != nil || cl.calls != 1 {
+        t.Errorf("esperaba la recompensa de consenso cacheada, llamadas=%d err=%v", cl.calls, err)
+    }
+}
+
+func TestProposalRewardUseCase_UsesProposerPayment(t *testing.T) {
+    uc := newProposalRewardUseCase(domain.BlockReward{
+        Slot:               9,
+        Status:             "mev",
+        RewardWei:          "900",
+        ProposerPaymentWei: "50000000000000000",
+    }, &mockCLRewards{reward: domain.ConsensusBlockReward{Total: 1}})
+
+    res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.ExecutionIncomeWei != "50000000000000000" || res.TotalWei != "50000001000000000" || !res.ExecutionIncomeVerified {
+        t.Errorf("esperaba el pago del builder como ingreso de ejecución: %+v", res)
+    }
+}
+
+func TestProposalRewardUseCase_BuilderWithoutPayment(t *testing.T) {
+    // The coinbase reward is the builder's profit, never the validator's.
+    el := domain.BlockReward{
+        Slot:           9,
+        Status:         "mev",
+        RewardWei:      "900",
+        FeeRecipient:   "0xfee",
+        BuilderAddress: "0xb1d",
+    }
+    uc := newProposalRewardUseCase(el, &mockCLRewards{reward: domain.ConsensusBlockReward{Total: 1}})
+    res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.ExecutionIncomeWei != "0" || res.TotalWei != "1000000000" || res.ExecutionIncomeVerified {
+        t.Errorf("esperaba ingreso 0 sin verificar: %+v", res)
+    }
+
+    // With relay data the claimed value is used, still unverified.
+    el.Relays = []domain.RelayDelivery{{Relay: "https://relay-a", ValueWei: "48000"}}
+    uc = newProposalRewardUseCase(el, &mockCLRewards{reward: domain.ConsensusBlockReward{Total: 1}})
+    res, err = uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.ExecutionIncomeWei != "48000" || res.ExecutionIncomeVerified {
+        t.Errorf("esperaba el valor del relay sin verificar: %+v", res)
+    }
+}
+
+func TestProposalRewardUseCase_BuilderBuiltWithoutAddress(t *testing.T) {
+    // Only a relay delivery or a known builder tells the block apart; the
+    // coinbase reward must not be taken as the validator's either way.
+    cases := []struct {
+        name    string
+        el      domain.BlockReward
+        wantWei string
+    }{
+        {
+            name:    "Relay",
+            el:      domain.BlockReward{Slot: 9, Status: "mev", RewardWei: "900", Relays: []domain.RelayDelivery{{Relay: "https://relay-a", ValueWei: "48000"}}},
+            wantWei: "48000",
+        },
+        {
+            name:    "KnownBuilder",
+            el:      domain.BlockReward{Slot: 9, Status: "mev", RewardWei: "900", Builder: "Titan"},
+            wantWei: "0",
+        },
+    }
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            uc := newProposalRewardUseCase(tc.el, &mockCLRewards{reward: domain.ConsensusBlockReward{Total: 1}})
+            res, err := uc.Execute(context.Background(), 9, domain.RewardMethodBalance)
+            if err != nil {
+                t.Fatalf("esperaba sin error, got %v", err)
+            }
+            if res.ExecutionIncomeWei != tc.wantWei || res.ExecutionIncomeVerified {
+                t.Errorf("esperaba ingreso %s sin verificar: %+v", tc.wantWei, res)
+            }
+        })
+    }
+}
+
+func TestProposalRewardUseCase_MissedSlot(t *testing.T) {
+    cl := &mockCLRewards{err: errors.New("no debe llamarse")}
+    uc := newProposalRewardUseCase(domain.BlockReward{}, cl)
+
+    res, err := uc.Execute(context.Background(), 10, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Status != domain.SlotStatusMissed || res.TotalWei != "0" || res.Consensus != nil || cl.calls != 0 {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+}
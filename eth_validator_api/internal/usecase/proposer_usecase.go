@@ -0,0 +1,72 @@
+package usecase
+
+import (
+    "context"
+
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+    "eth_validator_api/internal/domain"
+)
+
+type ProposerUseCase struct {
+    resolver port.SlotResolver
+    pubkeys  port.ValidatorPubkeyResolver
+    cache    port.ProposerCache
+    head     port.HeadTracker
+}
+
+func NewProposerUseCase(
+    resolver port.SlotResolver,
+    pubkeys port.ValidatorPubkeyResolver,
+    cache port.ProposerCache,
+    head port.HeadTracker,
+) *ProposerUseCase {
+    return &ProposerUseCase{resolver: resolver, pubkeys: pubkeys, cache: cache, head: head}
+}
+
+func (uc *ProposerUseCase) Execute(ctx context.Context, slot uint64) (domain.ProposerIdentity, error) {
+    if v, ok := uc.cache.Get(slot); ok {
+        return v, nil
+    }
+    if err := checkSlotReached(uc.head, slot, apierr.ErrSlotInFuture); err != nil {
+        return domain.ProposerIdentity{}, err
+    }
+
+    block, err := uc.resolver.ResolveSlot(ctx, slot)
+    if err != nil {
+        return domain.ProposerIdentity{}, err
+    }
+    return uc.fromBlock(ctx, block)
+}
+
+// fromBlock builds the proposer identity of an already resolved slot, so the
+// block reward can share it without resolving the slot twice.
+func (uc *ProposerUseCase) fromBlock(ctx context.Context, block domain.SlotBlock) (domain.ProposerIdentity, error) {
+    if v, ok := uc.cache.Get(block.Slot); ok {
+        return v, nil
+    }
+
+    proposer := domain.ProposerIdentity{
+        Finality:      block.Finality,
+        Slot:          block.Slot,
+        Status:        domain.SlotStatusProposed,
+        ProposerIndex: block.ProposerIndex,
+        Pubkey:        block.ProposerPubkey,
+        Graffiti:      block.Graffiti,
+        FeeRecipient:  block.FeeRecipient,
+    }
+    if block.Missed {
+        proposer.Status = domain.SlotStatusMissed
+        proposer.Finality = domain.Finality{Finalized: slotFinalized(uc.head, block.Slot)}
+    }
+    if proposer.Pubkey == "" {
+        pubkey, err := uc.pubkeys.ValidatorPubkey(ctx, block.ProposerIndex)
+        if err != nil {
+            return domain.ProposerIdentity{}, err
+        }
+        proposer.Pubkey = pubkey
+    }
+
+    uc.cache.Add(block.Slot, proposer)
+    return proposer, nil
+}
\ No newline at end of file
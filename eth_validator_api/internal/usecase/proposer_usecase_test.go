@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+    "context"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/usecase"
+)
+
+type mockPubkeys struct {
+    calls int
+}
+
+func (m *mockPubkeys) ValidatorPubkey(ctx context.Context, index uint64) (string, error) {
+    m.calls++
+    return "0xpubkey", nil
+}
+
+type graffitiResolver struct {
+    resolves int
+}
+
+func (m *graffitiResolver) ResolveSlot(ctx context.Context, slot uint64) (domain.SlotBlock, error) {
+    m.resolves++
+    return domain.SlotBlock{Slot: slot, ProposerIndex: 42, BlockNumber: 100, Graffiti: "Lighthouse", FeeRecipient: "0xfee"}, nil
+}
+
+type dummyCacheProposer struct {
+    store map[uint64]domain.ProposerIdentity
+}
+
+func (c *dummyCacheProposer) Get(slot uint64) (domain.ProposerIdentity, bool) {
+    p, ok := c.store[slot]
+    return p, ok
+}
+
+func (c *dummyCacheProposer) Add(slot uint64, proposer domain.ProposerIdentity) {
+    c.store[slot] = proposer
+}
+
+func TestProposerUseCase_ResolvesAndCaches(t *testing.T) {
+    pubkeys := &mockPubkeys{}
+    uc := usecase.NewProposerUseCase(&graffitiResolver{}, pubkeys, &dummyCacheProposer{store: map[uint64]domain.ProposerIdentity{}}, farHead)
+
+    for i := 0; i < 2; i++ {
+        res, err := uc.Execute(context.Background(), 10)
+        if err != nil {
+            t.Fatalf("esperaba sin error, got %v", err)
+        }
+        want := domain.ProposerIdentity{Slot: 10, Status: domain.SlotStatusProposed, ProposerIndex: 42, Pubkey: "0xpubkey", Graffiti: "Lighthouse", FeeRecipient: "0xfee"}
+        if res != want {
+            t.Errorf("resultado inesperado: %+v", res)
+        }
+    }
+    if pubkeys.calls != 1 {
+        t.Errorf("esperaba una sola consulta de pubkey, got %d", pubkeys.calls)
+    }
+}
+
+func TestProposerUseCase_MissedSlot(t *testing.T) {
+    uc := usecase.NewProposerUseCase(&mockResolver{missed: map[uint64]uint64{11: 7}}, &mockPubkeys{}, &dummyCacheProposer{store: map[uint64]domain.ProposerIdentity{}}, farHead)
+
+    res, err := uc.Execute(context.Background(), 11)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Status != domain.SlotStatusMissed || res.ProposerIndex != 7 || res.Pubkey != "0xpubkey" {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+}
+
+func TestProposerUseCase_SlotAfterHead(t *testing.T) {
+    uc := usecase.NewProposerUseCase(&mockResolver{}, &mockPubkeys{}, &dummyCacheProposer{store: map[uint64]domain.ProposerIdentity{}}, staticHead{slot: 5})
+    if _, err := uc.Execute(context.Background(), 6); err != apierr.ErrSlotInFuture {
+        t.Fatalf("esperaba ErrSlotInFuture, got %v", err)
+    }
+}
+
+func TestBlockRewardUseCase_IncludesProposer(t *testing.T) {
+    resolver := &graffitiResolver{}
+    cache := &dummyCacheProposer{store: map[uint64]domain.ProposerIdentity{}}
+    proposers := usecase.NewProposerUseCase(resolver, &mockPubkeys{}, cache, farHead)
+    uc := usecase.NewBlockRewardUseCase(resolver, &recordingBRClient{}, newdummyCacheBR(), farHead, nil, proposers)
+
+    res, err := uc.Execute(context.Background(), 10, domain.RewardMethodBalance)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.ProposerPubkey != "0xpubkey" || res.Graffiti != "Lighthouse" || res.FeeRecipient != "0xfee" {
+        t.Errorf("proposer inesperado: %+v", res)
+    }
+    if resolver.resolves != 1 {
+        t.Errorf("esperaba resolver el slot una sola vez, got %d", resolver.resolves)
+    }
+    if _, ok := cache.Get(10); !ok {
+        t.Error("esperaba compartir la caché de proposers")
+    }
+}
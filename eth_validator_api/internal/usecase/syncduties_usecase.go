@@ -2,37 +2,139 @@ package usecase
 
 import (
     "context"
+    "time"
 
     "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
     "eth_validator_api/internal/port"
 )
 
+const (
+    // The sync committee changes every 256 epochs (8192 slots).
+    epochsPerSyncCommitteePeriod = 256
+    secondsPerSlot               = 12
+)
+
 type SyncDutiesUseCase struct {
-    client port.SyncDutiesClient
-    cache  port.SyncDutiesCache
+    proposers       *ProposerUseCase
+    client          port.SyncDutiesClient
+    cache           port.SyncDutiesCache
+    head            port.HeadTracker
+    genesis         port.GenesisClient
+    altairForkEpoch uint64
 }
 
 func NewSyncDutiesUseCase(
+    proposers *ProposerUseCase,
     client port.SyncDutiesClient,
     cache port.SyncDutiesCache,
+    head port.HeadTracker,
+    genesis port.GenesisClient,
+    altairForkEpoch uint64,
 ) *SyncDutiesUseCase {
-    return &SyncDutiesUseCase{client: client, cache: cache}
+    return &SyncDutiesUseCase{proposers: proposers, client: client, cache: cache, head: head, genesis: genesis, altairForkEpoch: altairForkEpoch}
+}
+
+func syncCommitteePeriod(slot uint64) uint64 {
+    return slot / slotsPerEpoch / epochsPerSyncCommitteePeriod
 }
 
 func (uc *SyncDutiesUseCase) Execute(
     ctx context.Context,
     slot uint64,
 ) (domain.SyncDuties, error) {
-    
-    if v, ok := uc.cache.Get(slot); ok {
-        return v, nil
+    if err := checkSlotReached(uc.head, slot, apierr.ErrSlotTooFarInFuture); err != nil {
+        return domain.SyncDuties{}, err
+    }
+
+    committee, err := uc.committee(ctx, slot, slot/slotsPerEpoch)
+    if err != nil {
+        return domain.SyncDuties{}, err
     }
-   
-    duties, err := uc.client.GetSyncDuties(ctx, slot)
+
+    // The slot's status and proposer come from the proposer cache, so
+    // repeated requests don't fetch the block again.
+    proposer, err := uc.proposers.Execute(ctx, slot)
     if err != nil {
         return domain.SyncDuties{}, err
     }
+    return domain.SyncDuties{
+        Finality:      proposer.Finality,
+        Slot:          slot,
+        Status:        proposer.Status,
+        ProposerIndex: proposer.ProposerIndex,
+        Validators:    committee.Validators,
+        Subcommittees: committee.Subcommittees,
+    }, nil
+}
+
+// Next previews the committee of the period after the head's.
+func (uc *SyncDutiesUseCase) Next(ctx context.Context) (domain.SyncCommitteePreview, error) {
+    h, ok := uc.head.Head()
+    if !ok {
+        return domain.SyncCommitteePreview{}, apierr.ErrHeadUnavailable
+    }
+    nextEpoch := (syncCommitteePeriod(h.HeadSlot) + 1) * epochsPerSyncCommitteePeriod
+    return uc.ForEpoch(ctx, h.HeadSlot, nextEpoch)
+}
+
+// ForEpoch returns the committee serving an epoch as seen from the state at
+// the slot. A state only knows its current and next committees.
+func (uc *SyncDutiesUseCase) ForEpoch(ctx context.Context, slot, epoch uint64) (domain.SyncCommitteePreview, error) {
+    if err := checkSlotReached(uc.head, slot, apierr.ErrSlotTooFarInFuture); err != nil {
+        return domain.SyncCommitteePreview{}, err
+    }
+    period := epoch / epochsPerSyncCommitteePeriod
+    if current := syncCommitteePeriod(slot); period != current && period != current+1 {
+        return domain.SyncCommitteePreview{}, apierr.ErrEpochOutOfRange
+    }
+
+    committee, err := uc.committee(ctx, slot, epoch)
+    if err != nil {
+        return domain.SyncCommitteePreview{}, err
+    }
+    genesis, err := uc.genesis.GenesisTime(ctx)
+    if err != nil {
+        return domain.SyncCommitteePreview{}, err
+    }
 
-    uc.cache.Add(slot, duties)
-    return duties, nil
+    startEpoch := period * epochsPerSyncCommitteePeriod
+    startSlot := startEpoch * slotsPerEpoch
+    return domain.SyncCommitteePreview{
+        Finality:      committee.Finality,
+        Period:        period,
+        StartEpoch:    startEpoch,
+        StartSlot:     startSlot,
+        StartTime:     genesis.Add(time.Duration(startSlot*secondsPerSlot) * time.Second),
+        Validators:    committee.Validators,
+        Subcommittees: committee.Subcommittees,
+    }, nil
 }
+
+// committee returns the sync committee serving the epoch, read from the state
+// at the slot and fetched once per period. Before Altair there is no
+// committee; if the fork falls inside a period, the epochs before it still
+// have none.
+//
+// A period's committee is fixed as next_sync_committee when the period before
+// it starts. Once that slot is finalized the committee can't change, whatever
+// state it was read from, so it is marked finalized and kept in the cache.
+func (uc *SyncDutiesUseCase) committee(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error) {
+    if epoch < uc.altairForkEpoch {
+        return domain.SyncDuties{Validators: []string{}, Subcommittees: []domain.SyncSubcommittee{}}, nil
+    }
+
+    period := epoch / epochsPerSyncCommitteePeriod
+    if v, ok := uc.cache.Get(period); ok {
+        return v, nil
+    }
+    committee, err := uc.client.GetSyncDuties(ctx, slot, epoch)
+    if err != nil {
+        return domain.SyncDuties{}, err
+    }
+    if period == 0 || slotFinalized(uc.head, (period-1)*epochsPerSyncCommitteePeriod*slotsPerEpoch) {
+        committee.Finalized = true
+    }
+    uc.cache.Add(period, committee)
+    return committee, nil
+}
\ No newline at end of file
@@ -4,18 +4,24 @@ import (
     "context"
     "errors"
     "testing"
+    "time"
 
     apierr "eth_validator_api/internal/errors"
     "eth_validator_api/internal/domain"
+    "eth_validator_api/internal/port"
     "eth_validator_api/internal/usecase"
 )
 
 type dummyClient struct {
     duties domain.SyncDuties
     err    error
+    slots  []uint64
+    epochs []uint64
 }
 
-func (m *dummyClient) GetSyncDuties(ctx context.Context, slot uint64) (domain.SyncDuties, error) {
+func (m *dummyClient) GetSyncDuties(ctx context.Context, slot, epoch uint64) (domain.SyncDuties, error) {
+    m.slots = append(m.slots, slot)
+    m.epochs = append(m.epochs, epoch)
     return m.duties, m.err
 }
 
@@ -36,11 +42,22 @@ func (c *dummyCache) Add(slot uint64, duties domain.SyncDuties) {
     c.store[slot] = duties
 }
 
+// newProposers resolves slots through resolver with an empty proposer cache.
+func newProposers(resolver port.SlotResolver) *usecase.ProposerUseCase {
+    return usecase.NewProposerUseCase(resolver, &mockPubkeys{}, &dummyCacheProposer{store: map[uint64]domain.ProposerIdentity{}}, farHead)
+}
+
+type fixedGenesis time.Time
+
+func (g fixedGenesis) GenesisTime(ctx context.Context) (time.Time, error) {
+    return time.Time(g), nil
+}
+
 func TestSyncDutiesUseCase_CacheMiss(t *testing.T) {
     want := domain.SyncDuties{Validators: []string{"A"}}
     client := &dummyClient{duties: want, err: nil}
     cache := newDummyCache()
-    uc := usecase.NewSyncDutiesUseCase(client, cache)
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, farHead, nil, 0)
 
     got, err := uc.Execute(context.Background(), 42)
     if err != nil {
@@ -49,8 +66,8 @@ func TestSyncDutiesUseCase_CacheMiss(t *testing.T) {
     if len(got.Validators) != 1 || got.Validators[0] != "A" {
         t.Errorf("unexpected result: %+v", got.Validators)
     }
-    if _, found := cache.Get(42); !found {
-        t.Error("esperaba que la respuesta se guardase en caché")
+    if _, found := cache.Get(0); !found {
+        t.Error("esperaba que el comité se guardase en caché por periodo")
     }
 }
 
@@ -58,8 +75,8 @@ func TestSyncDutiesUseCase_CacheHit(t *testing.T) {
     want := domain.SyncDuties{Validators: []string{"B"}}
     client := &dummyClient{duties: domain.SyncDuties{}, err: errors.New("no debe llamarse")}
     cache := newDummyCache()
-    cache.Add(99, want)
-    uc := usecase.NewSyncDutiesUseCase(client, cache)
+    cache.Add(0, want)
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, farHead, nil, 0)
 
     got, err := uc.Execute(context.Background(), 99)
     if err != nil {
@@ -73,13 +90,13 @@ func TestSyncDutiesUseCase_CacheHit(t *testing.T) {
 func TestSyncDutiesUseCase_ClientError(t *testing.T) {
     client := &dummyClient{duties: domain.SyncDuties{}, err: errors.New("RPC falló")}
     cache := newDummyCache()
-    uc := usecase.NewSyncDutiesUseCase(client, cache)
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, farHead, nil, 0)
 
     _, err := uc.Execute(context.Background(), 7)
     if err == nil {
         t.Fatal("esperaba error del cliente")
     }
-    if _, found := cache.Get(7); found {
+    if _, found := cache.Get(0); found {
         t.Error("no esperaba que se cachease tras un error")
     }
 }
@@ -87,13 +104,13 @@ func TestSyncDutiesUseCase_ClientError(t *testing.T) {
 func TestSyncDutiesUseCase_SlotTooFarInFuture(t *testing.T) {
     client := &dummyClient{duties: domain.SyncDuties{}, err: apierr.ErrSlotTooFarInFuture}
     cache := newDummyCache()
-    uc := usecase.NewSyncDutiesUseCase(client, cache)
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, farHead, nil, 0)
 
     _, err := uc.Execute(context.Background(), 123)
     if err != apierr.ErrSlotTooFarInFuture {
         t.Fatalf("esperaba ErrSlotTooFarInFuture, got %v", err)
     }
-    if _, found := cache.Get(123); found {
+    if _, found := cache.Get(0); found {
         t.Error("no esperaba que se cachease un slot futuro")
     }
 }
@@ -101,13 +118,229 @@ func TestSyncDutiesUseCase_SlotTooFarInFuture(t *testing.T) {
 func TestSyncDutiesUseCase_SlotNotFound(t *testing.T) {
     client := &dummyClient{duties: domain.SyncDuties{}, err: apierr.ErrSlotNotFound}
     cache := newDummyCache()
-    uc := usecase.NewSyncDutiesUseCase(client, cache)
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, farHead, nil, 0)
 
     _, err := uc.Execute(context.Background(), 8)
     if err != apierr.ErrSlotNotFound {
         t.Fatalf("esperaba ErrSlotNotFound, got %v", err)
     }
-    if _, found := cache.Get(8); found {
+    if _, found := cache.Get(0); found {
         t.Error("no esperaba que se cachease un slot no encontrado")
     }
 }
+
+func TestSyncDutiesUseCase_MissedSlot(t *testing.T) {
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"A"}}}
+    resolver := &mockResolver{missed: map[uint64]uint64{64: 1234}}
+    cache := newDummyCache()
+    uc := usecase.NewSyncDutiesUseCase(newProposers(resolver), client, cache, farHead, nil, 0)
+
+    got, err := uc.Execute(context.Background(), 64)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if got.Status != domain.SlotStatusMissed || got.ProposerIndex != 1234 {
+        t.Errorf("resultado inesperado: %+v", got)
+    }
+    if len(got.Validators) != 1 {
+        t.Errorf("esperaba el comité aunque el slot se perdiese: %+v", got.Validators)
+    }
+    if _, found := cache.Get(0); !found {
+        t.Error("esperaba que el comité de un slot perdido se guardase en caché")
+    }
+}
+
+func TestSyncDutiesUseCase_SlotAfterHead(t *testing.T) {
+    client := &dummyClient{err: errors.New("no debe llamarse")}
+    cache := newDummyCache()
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, staticHead{slot: 10}, nil, 0)
+
+    _, err := uc.Execute(context.Background(), 11)
+    if err != apierr.ErrSlotTooFarInFuture {
+        t.Fatalf("esperaba ErrSlotTooFarInFuture, got %v", err)
+    }
+}
+
+func TestSyncDutiesUseCase_OneFetchPerPeriod(t *testing.T) {
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"A"}}}
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, nil, 0)
+
+    // 8191 is the last slot of period 0 and 8192 the first of period 1.
+    for _, slot := range []uint64{8192, 100, 8191, 9000, 16383, 16384} {
+        got, err := uc.Execute(context.Background(), slot)
+        if err != nil {
+            t.Fatalf("slot %d: esperaba sin error, got %v", slot, err)
+        }
+        if got.Slot != slot {
+            t.Errorf("slot = %d, esperaba %d", got.Slot, slot)
+        }
+    }
+    want := []uint64{8192, 100, 16384}
+    if len(client.slots) != len(want) {
+        t.Fatalf("esperaba una consulta por periodo %v, got %v", want, client.slots)
+    }
+    for i := range want {
+        if client.slots[i] != want[i] {
+            t.Errorf("consultas = %v, esperaba %v", client.slots, want)
+        }
+    }
+}
+
+func TestSyncDutiesUseCase_FixedCommitteeIsFinal(t *testing.T) {
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"A"}}}
+    cache := newDummyCache()
+    // Period 2's committee was fixed at slot 8192, the start of period 1.
+    head := staticHead{slot: 20000, finalized: 8192}
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, head, fixedGenesis(time.Unix(0, 0)), 0)
+
+    if _, err := uc.Execute(context.Background(), 16384); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if got, _ := cache.Get(2); !got.Finalized {
+        t.Error("esperaba cachear como final un comité ya fijado, aunque venga de un estado sin finalizar")
+    }
+
+    // Period 3's is fixed at slot 16384, which isn't finalized yet.
+    if _, err := uc.ForEpoch(context.Background(), 20000, 3*256); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if got, _ := cache.Get(3); got.Finalized {
+        t.Error("no esperaba marcar como final un comité aún sin fijar")
+    }
+}
+
+func TestSyncDutiesUseCase_CachesSlotProposer(t *testing.T) {
+    resolver := &graffitiResolver{}
+    uc := usecase.NewSyncDutiesUseCase(newProposers(resolver), &dummyClient{duties: domain.SyncDuties{Validators: []string{"A"}}}, newDummyCache(), farHead, nil, 0)
+
+    for i := 0; i < 2; i++ {
+        got, err := uc.Execute(context.Background(), 10)
+        if err != nil {
+            t.Fatalf("esperaba sin error, got %v", err)
+        }
+        if got.ProposerIndex != 42 || got.Status != domain.SlotStatusProposed {
+            t.Errorf("resultado inesperado: %+v", got)
+        }
+    }
+    if resolver.resolves != 1 {
+        t.Errorf("esperaba resolver el slot una sola vez, got %d", resolver.resolves)
+    }
+}
+
+func TestSyncDutiesUseCase_AltairFork(t *testing.T) {
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"A"}}}
+    cache := newDummyCache()
+    // Fork at epoch 50, inside period 0 (epochs 0-255).
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, farHead, nil, 50)
+
+    before, err := uc.Execute(context.Background(), 50*32-1)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(before.Validators) != 0 || len(client.slots) != 0 {
+        t.Errorf("no esperaba comité antes de Altair: %+v", before)
+    }
+    if _, found := cache.Get(0); found {
+        t.Error("no esperaba cachear el periodo con un slot anterior a Altair")
+    }
+
+    after, err := uc.Execute(context.Background(), 50*32)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(after.Validators) != 1 || len(client.slots) != 1 {
+        t.Errorf("esperaba el comité tras Altair: %+v", after)
+    }
+}
+func TestSyncDutiesUseCase_ForEpoch(t *testing.T) {
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"A", "B"}}}
+    cache := newDummyCache()
+    genesis := time.Unix(1606824023, 0).UTC()
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, cache, farHead, fixedGenesis(genesis), 0)
+
+    // Slot 100 is in period 0; epoch 300 is in period 1.
+    got, err := uc.ForEpoch(context.Background(), 100, 300)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if got.Period != 1 || got.StartEpoch != 256 || got.StartSlot != 8192 {
+        t.Errorf("esperaba periodo 1 desde la época 256 (slot 8192), got %+v", got)
+    }
+    if want := genesis.Add(8192 * 12 * time.Second); !got.StartTime.Equal(want) {
+        t.Errorf("StartTime = %v, esperaba %v", got.StartTime, want)
+    }
+    if len(got.Validators) != 2 {
+        t.Errorf("esperaba 2 validadores, got %v", got.Validators)
+    }
+    if len(client.epochs) != 1 || client.slots[0] != 100 || client.epochs[0] != 300 {
+        t.Errorf("esperaba consultar el estado del slot 100 con la época 300, got slots %v épocas %v", client.slots, client.epochs)
+    }
+    if _, found := cache.Get(1); !found {
+        t.Error("esperaba que el comité del periodo 1 se guardase en caché")
+    }
+
+    // Once the period starts, Execute reuses the cached committee.
+    if _, err := uc.Execute(context.Background(), 8192); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(client.slots) != 1 {
+        t.Errorf("esperaba reutilizar el comité en caché, got consultas %v", client.slots)
+    }
+}
+
+func TestSyncDutiesUseCase_ForEpochOutOfRange(t *testing.T) {
+    client := &dummyClient{err: errors.New("no debe llamarse")}
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, fixedGenesis(time.Unix(0, 0)), 0)
+
+    // Slot 8192 is in period 1: period 0 is past and period 3 is unknown.
+    for _, epoch := range []uint64{255, 768} {
+        if _, err := uc.ForEpoch(context.Background(), 8192, epoch); err != apierr.ErrEpochOutOfRange {
+            t.Errorf("época %d: esperaba ErrEpochOutOfRange, got %v", epoch, err)
+        }
+    }
+}
+
+func TestSyncDutiesUseCase_Next(t *testing.T) {
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"A"}}}
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), staticHead{slot: 9000}, fixedGenesis(time.Unix(0, 0)), 0)
+
+    got, err := uc.Next(context.Background())
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if got.Period != 2 || got.StartEpoch != 512 {
+        t.Errorf("esperaba el periodo 2 desde la época 512, got %+v", got)
+    }
+    if len(client.slots) != 1 || client.slots[0] != 9000 || client.epochs[0] != 512 {
+        t.Errorf("esperaba consultar el estado de la cabeza, got slots %v épocas %v", client.slots, client.epochs)
+    }
+
+    unsynced := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), staticHead{unsynced: true}, fixedGenesis(time.Unix(0, 0)), 0)
+    if _, err := unsynced.Next(context.Background()); err != apierr.ErrHeadUnavailable {
+        t.Errorf("esperaba ErrHeadUnavailable, got %v", err)
+    }
+}
+func TestSyncDutiesUseCase_Subcommittees(t *testing.T) {
+    subcommittees := []domain.SyncSubcommittee{
+        {Subnet: 0, Members: []domain.SyncCommitteeMember{{ValidatorIndex: 7, Pubkey: "A"}}},
+        {Subnet: 1, Members: []domain.SyncCommitteeMember{{ValidatorIndex: 8, Pubkey: "B"}}},
+    }
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"A", "B"}, Subcommittees: subcommittees}}
+    uc := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, nil, 50)
+
+    got, err := uc.Execute(context.Background(), 50*32)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(got.Subcommittees) != 2 || got.Subcommittees[1].Members[0].ValidatorIndex != 8 {
+        t.Errorf("esperaba los subcomités del comité, got %+v", got.Subcommittees)
+    }
+
+    before, err := uc.Execute(context.Background(), 0)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if before.Subcommittees == nil || len(before.Subcommittees) != 0 {
+        t.Errorf("esperaba una lista de subcomités vacía antes de Altair, got %+v", before.Subcommittees)
+    }
+}
\ No newline at end of file
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+    "context"
+    "strconv"
+    "strings"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+// SyncParticipationUseCase joins the sync aggregate of the block at a slot
+// with the committee SyncDutiesUseCase caches per period.
+type SyncParticipationUseCase struct {
+    resolver port.SlotResolver
+    duties   *SyncDutiesUseCase
+}
+
+func NewSyncParticipationUseCase(
+    resolver port.SlotResolver,
+    duties *SyncDutiesUseCase,
+) *SyncParticipationUseCase {
+    return &SyncParticipationUseCase{resolver: resolver, duties: duties}
+}
+
+// Execute reports which committee positions signed the slot's sync
+// aggregate. The participation rate always covers the whole committee; the
+// filter only narrows the listed positions.
+func (uc *SyncParticipationUseCase) Execute(
+    ctx context.Context,
+    slot uint64,
+    filter []string,
+) (domain.SyncParticipation, error) {
+    ids, err := normalizeValidatorIDs(filter)
+    if err != nil {
+        return domain.SyncParticipation{}, err
+    }
+    if err := checkSlotReached(uc.duties.head, slot, apierr.ErrSlotInFuture); err != nil {
+        return domain.SyncParticipation{}, err
+    }
+
+    block, err := uc.resolver.ResolveSlot(ctx, slot)
+    if err != nil {
+        return domain.SyncParticipation{}, err
+    }
+    participation := domain.SyncParticipation{
+        Finality:     block.Finality,
+        Slot:         slot,
+        Status:       domain.SlotStatusProposed,
+        Period:       syncCommitteePeriod(slot),
+        Participated: []domain.SyncCommitteeSeat{},
+        Missed:       []domain.SyncCommitteeSeat{},
+    }
+    // Without a block there is no sync aggregate to read.
+    if block.Missed {
+        participation.Status = domain.SlotStatusMissed
+        participation.Finality = domain.Finality{Finalized: slotFinalized(uc.duties.head, slot)}
+        return participation, nil
+    }
+
+    committee, err := uc.duties.committee(ctx, slot, slot/slotsPerEpoch)
+    if err != nil {
+        return domain.SyncParticipation{}, err
+    }
+
+    wanted := make(map[string]struct{}, len(ids))
+    for _, id := range ids {
+        wanted[id] = struct{}{}
+    }
+    var position, signed int
+    for _, sub := range committee.Subcommittees {
+        for _, member := range sub.Members {
+            seat := domain.SyncCommitteeSeat{Position: position, ValidatorIndex: member.ValidatorIndex, Pubkey: member.Pubkey}
+            participated := bitSet(block.SyncCommitteeBits, position)
+            position++
+            if participated {
+                signed++
+            }
+            if len(wanted) > 0 && !seatWanted(wanted, seat) {
+                continue
+            }
+            if participated {
+                participation.Participated = append(participation.Participated, seat)
+            } else {
+                participation.Missed = append(participation.Missed, seat)
+            }
+        }
+    }
+    if position > 0 {
+        participation.ParticipationRate = float64(signed) / float64(position)
+    }
+    return participation, nil
+}
+
+// bitSet reads an SSZ bitvector, which stores the lowest position in the
+// least significant bit of the first byte.
+func bitSet(bits []byte, i int) bool {
+    if i/8 >= len(bits) {
+        return false
+    }
+    return bits[i/8]&(1<<(i%8)) != 0
+}
+
+func seatWanted(wanted map[string]struct{}, seat domain.SyncCommitteeSeat) bool {
+    if _, ok := wanted[strconv.FormatUint(seat.ValidatorIndex, 10)]; ok {
+        return true
+    }
+    _, ok := wanted[strings.ToLower(seat.Pubkey)]
+    return ok
+}
\ No newline at end of file
@@ -0,0 +1,116 @@
+package usecase_test
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/usecase"
+)
+
+type bitsResolver struct {
+    bits   []byte
+    missed bool
+}
+
+func (m *bitsResolver) ResolveSlot(ctx context.Context, slot uint64) (domain.SlotBlock, error) {
+    return domain.SlotBlock{Slot: slot, Missed: m.missed, SyncCommitteeBits: m.bits}, nil
+}
+
+// participationCommittee has ten positions over two subcommittees, with
+// validator 13 holding positions 3 and 8.
+func participationCommittee() domain.SyncDuties {
+    members := func(indices ...uint64) []domain.SyncCommitteeMember {
+        out := make([]domain.SyncCommitteeMember, len(indices))
+        for i, idx := range indices {
+            out[i] = domain.SyncCommitteeMember{ValidatorIndex: idx, Pubkey: fmt.Sprintf("0x%s%02d", strings.Repeat("ab", 47), idx)}
+        }
+        return out
+    }
+    return domain.SyncDuties{Subcommittees: []domain.SyncSubcommittee{
+        {Subnet: 0, Members: members(10, 11, 12, 13, 14)},
+        {Subnet: 1, Members: members(15, 16, 17, 13, 19)},
+    }}
+}
+
+func TestSyncParticipationUseCase_Bits(t *testing.T) {
+    client := &dummyClient{duties: participationCommittee()}
+    duties := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, nil, 0)
+    // Positions 0, 3 and 9 signed: 0b00001001, 0b00000010.
+    uc := usecase.NewSyncParticipationUseCase(&bitsResolver{bits: []byte{0x09, 0x02}}, duties)
+
+    got, err := uc.Execute(context.Background(), 100, nil)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(got.Participated) != 3 || len(got.Missed) != 7 {
+        t.Fatalf("esperaba 3 firmas y 7 ausencias, got %+v", got)
+    }
+    if got.Participated[1].Position != 3 || got.Participated[1].ValidatorIndex != 13 || got.Participated[2].ValidatorIndex != 19 {
+        t.Errorf("participantes inesperados: %+v", got.Participated)
+    }
+    if got.ParticipationRate != 0.3 {
+        t.Errorf("participation_rate = %v, esperaba 0.3", got.ParticipationRate)
+    }
+    if got.Status != domain.SlotStatusProposed || got.Period != 0 {
+        t.Errorf("slot inesperado: %+v", got)
+    }
+}
+
+func TestSyncParticipationUseCase_Filter(t *testing.T) {
+    client := &dummyClient{duties: participationCommittee()}
+    duties := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, nil, 0)
+    uc := usecase.NewSyncParticipationUseCase(&bitsResolver{bits: []byte{0x09, 0x02}}, duties)
+
+    // Validator 13 signed in position 3 but not in position 8; 16 is given
+    // by pubkey.
+    pubkey := "0x" + strings.Repeat("AB", 47) + "16"
+    got, err := uc.Execute(context.Background(), 100, []string{"13", pubkey, "99"})
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(got.Participated) != 1 || got.Participated[0].Position != 3 {
+        t.Errorf("participantes inesperados: %+v", got.Participated)
+    }
+    if len(got.Missed) != 2 || got.Missed[0].ValidatorIndex != 16 || got.Missed[1].Position != 8 {
+        t.Errorf("ausencias inesperadas: %+v", got.Missed)
+    }
+    if got.ParticipationRate != 0.3 {
+        t.Errorf("esperaba la tasa del comité completo, got %v", got.ParticipationRate)
+    }
+    if len(client.slots) != 1 {
+        t.Errorf("esperaba una consulta del comité, got %v", client.slots)
+    }
+}
+
+func TestSyncParticipationUseCase_MissedSlot(t *testing.T) {
+    client := &dummyClient{duties: participationCommittee()}
+    duties := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, nil, 0)
+    uc := usecase.NewSyncParticipationUseCase(&bitsResolver{missed: true}, duties)
+
+    got, err := uc.Execute(context.Background(), 100, nil)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if got.Status != domain.SlotStatusMissed || len(got.Participated) != 0 || len(got.Missed) != 0 || got.ParticipationRate != 0 {
+        t.Errorf("esperaba un slot perdido sin agregado, got %+v", got)
+    }
+    if len(client.slots) != 0 {
+        t.Errorf("no esperaba consultar el comité, got %v", client.slots)
+    }
+}
+
+func TestSyncParticipationUseCase_Errors(t *testing.T) {
+    duties := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), &dummyClient{}, newDummyCache(), staticHead{slot: 10}, nil, 0)
+    uc := usecase.NewSyncParticipationUseCase(&bitsResolver{}, duties)
+
+    if _, err := uc.Execute(context.Background(), 11, nil); err != apierr.ErrSlotInFuture {
+        t.Errorf("esperaba ErrSlotInFuture, got %v", err)
+    }
+    if _, err := uc.Execute(context.Background(), 5, []string{"abc"}); err != apierr.ErrInvalidValidatorID {
+        t.Errorf("esperaba ErrInvalidValidatorID, got %v", err)
+    }
+}
\ No newline at end of file
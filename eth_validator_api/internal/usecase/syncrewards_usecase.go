@@ -0,0 +1,90 @@
+package usecase
+
+import (
+    "context"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+// SyncRewardsUseCase fetches the rewards of the whole committee once per
+// slot and filters them per request, so every filter shares a cache entry.
+type SyncRewardsUseCase struct {
+    resolver   port.SlotResolver
+    client     port.SyncCommitteeRewardsClient
+    validators port.ValidatorIndexResolver
+    cache      port.SyncCommitteeRewardsCache
+    head       port.HeadTracker
+}
+
+func NewSyncRewardsUseCase(
+    resolver port.SlotResolver,
+    client port.SyncCommitteeRewardsClient,
+    validators port.ValidatorIndexResolver,
+    cache port.SyncCommitteeRewardsCache,
+    head port.HeadTracker,
+) *SyncRewardsUseCase {
+    return &SyncRewardsUseCase{resolver: resolver, client: client, validators: validators, cache: cache, head: head}
+}
+
+func (uc *SyncRewardsUseCase) Execute(
+    ctx context.Context,
+    slot uint64,
+    filter []string,
+) (domain.SyncCommitteeRewards, error) {
+    ids, err := normalizeValidatorIDs(filter)
+    if err != nil {
+        return domain.SyncCommitteeRewards{}, err
+    }
+
+    rewards, ok := uc.cache.Get(slot)
+    if !ok {
+        rewards, err = uc.fetch(ctx, slot)
+        if err != nil {
+            return domain.SyncCommitteeRewards{}, err
+        }
+        uc.cache.Add(slot, rewards)
+    }
+    if len(ids) == 0 {
+        return rewards, nil
+    }
+
+    indices, err := uc.validators.ValidatorIndices(ctx, slot, ids)
+    if err != nil {
+        return domain.SyncCommitteeRewards{}, err
+    }
+    wanted := make(map[uint64]struct{}, len(indices))
+    for _, idx := range indices {
+        wanted[idx] = struct{}{}
+    }
+    filtered := rewards
+    filtered.Rewards = []domain.SyncCommitteeReward{}
+    for _, r := range rewards.Rewards {
+        if _, ok := wanted[r.ValidatorIndex]; ok {
+            filtered.Rewards = append(filtered.Rewards, r)
+        }
+    }
+    return filtered, nil
+}
+
+func (uc *SyncRewardsUseCase) fetch(ctx context.Context, slot uint64) (domain.SyncCommitteeRewards, error) {
+    if err := checkSlotReached(uc.head, slot, apierr.ErrSlotInFuture); err != nil {
+        return domain.SyncCommitteeRewards{}, err
+    }
+    block, err := uc.resolver.ResolveSlot(ctx, slot)
+    if err != nil {
+        return domain.SyncCommitteeRewards{}, err
+    }
+    // Without a block there is no sync aggregate, so nobody was rewarded
+    // or penalized for the slot.
+    if block.Missed {
+        return domain.SyncCommitteeRewards{
+            Finality: domain.Finality{Finalized: slotFinalized(uc.head, slot)},
+            Slot:     slot,
+            Status:   domain.SlotStatusMissed,
+            Rewards:  []domain.SyncCommitteeReward{},
+        }, nil
+    }
+    return uc.client.GetSyncCommitteeRewards(ctx, slot)
+}
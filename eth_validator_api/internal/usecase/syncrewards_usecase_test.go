@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+    "context"
+    "errors"
+    "strconv"
+    "strings"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    "eth_validator_api/internal/usecase"
+)
+
+type mockSyncRewards struct {
+    calls int
+    err   error
+}
+
+func (m *mockSyncRewards) GetSyncCommitteeRewards(ctx context.Context, slot uint64) (domain.SyncCommitteeRewards, error) {
+    m.calls++
+    if m.err != nil {
+        return domain.SyncCommitteeRewards{}, m.err
+    }
+    return domain.SyncCommitteeRewards{Slot: slot, Status: domain.SlotStatusProposed, Rewards: []domain.SyncCommitteeReward{
+        {ValidatorIndex: 1, Reward: 20000},
+        {ValidatorIndex: 2, Reward: -20000},
+        {ValidatorIndex: 3, Reward: 20000},
+    }}, nil
+}
+
+// mockIndexResolver maps pubkeys "0x…<n>" to index n.
+type mockIndexResolver struct{}
+
+func (m *mockIndexResolver) ValidatorIndices(ctx context.Context, slot uint64, ids []string) ([]uint64, error) {
+    out := make([]uint64, len(ids))
+    for i, id := range ids {
+        idx, err := strconv.ParseUint(id[len(id)-1:], 10, 64)
+        if err != nil {
+            return nil, err
+        }
+        out[i] = idx
+    }
+    return out, nil
+}
+
+type dummyCacheSR struct {
+    store map[uint64]domain.SyncCommitteeRewards
+}
+
+func (c *dummyCacheSR) Get(slot uint64) (domain.SyncCommitteeRewards, bool) {
+    r, ok := c.store[slot]
+    return r, ok
+}
+
+func (c *dummyCacheSR) Add(slot uint64, rewards domain.SyncCommitteeRewards) {
+    c.store[slot] = rewards
+}
+
+func TestSyncRewardsUseCase_FiltersFromCachedCommittee(t *testing.T) {
+    client := &mockSyncRewards{}
+    uc := usecase.NewSyncRewardsUseCase(&mockResolver{}, client, &mockIndexResolver{}, &dummyCacheSR{store: map[uint64]domain.SyncCommitteeRewards{}}, farHead)
+
+    all, err := uc.Execute(context.Background(), 50, nil)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(all.Rewards) != 3 {
+        t.Errorf("esperaba el comité completo, got %+v", all.Rewards)
+    }
+
+    pubkey := "0x" + strings.Repeat("ab", 47) + "02"
+    filtered, err := uc.Execute(context.Background(), 50, []string{"3", pubkey})
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if len(filtered.Rewards) != 2 || filtered.Rewards[0].ValidatorIndex != 2 || filtered.Rewards[0].Reward != -20000 {
+        t.Errorf("filtro inesperado: %+v", filtered.Rewards)
+    }
+    if client.calls != 1 {
+        t.Errorf("esperaba una sola llamada por slot, got %d", client.calls)
+    }
+    if len(all.Rewards) != 3 {
+        t.Error("el filtro no debe modificar la entrada en caché")
+    }
+}
+
+func TestSyncRewardsUseCase_MissedSlot(t *testing.T) {
+    client := &mockSyncRewards{err: errors.New("no debe llamarse")}
+    uc := usecase.NewSyncRewardsUseCase(&mockResolver{missed: map[uint64]uint64{51: 9}}, client, &mockIndexResolver{}, &dummyCacheSR{store: map[uint64]domain.SyncCommitteeRewards{}}, farHead)
+
+    res, err := uc.Execute(context.Background(), 51, nil)
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Status != domain.SlotStatusMissed || len(res.Rewards) != 0 || client.calls != 0 {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+}
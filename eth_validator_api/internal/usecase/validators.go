@@ -0,0 +1,46 @@
+package usecase
+
+import (
+    "sort"
+    "strconv"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+
+    apierr "eth_validator_api/internal/errors"
+)
+
+const pubkeyLength = 48
+
+// normalizeValidatorIDs validates validator indices and pubkeys and returns
+// them lowercased, deduplicated and sorted, so the same set always maps to
+// the same cache entry.
+func normalizeValidatorIDs(ids []string) ([]string, error) {
+    seen := make(map[string]struct{}, len(ids))
+    out := make([]string, 0, len(ids))
+    for _, id := range ids {
+        id = strings.ToLower(strings.TrimSpace(id))
+        if id == "" {
+            continue
+        }
+        if !validValidatorID(id) {
+            return nil, apierr.ErrInvalidValidatorID
+        }
+        if _, ok := seen[id]; ok {
+            continue
+        }
+        seen[id] = struct{}{}
+        out = append(out, id)
+    }
+    sort.Strings(out)
+    return out, nil
+}
+
+func validValidatorID(id string) bool {
+    if strings.HasPrefix(id, "0x") {
+        b, err := hexutil.Decode(id)
+        return err == nil && len(b) == pubkeyLength
+    }
+    _, err := strconv.ParseUint(id, 10, 64)
+    return err == nil
+}
\ No newline at end of file
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+    "context"
+    "strconv"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+// ValidatorStatusUseCase reports a validator's status and balances. It isn't
+// cached: head and finalized move, and on-call wants what the node sees now.
+type ValidatorStatusUseCase struct {
+    client port.ValidatorStatusClient
+    head   port.HeadTracker
+}
+
+func NewValidatorStatusUseCase(
+    client port.ValidatorStatusClient,
+    head port.HeadTracker,
+) *ValidatorStatusUseCase {
+    return &ValidatorStatusUseCase{client: client, head: head}
+}
+
+// Execute looks the validator up in the state named by state: "head" (the
+// default), "finalized" or a slot number.
+func (uc *ValidatorStatusUseCase) Execute(
+    ctx context.Context,
+    id string,
+    state string,
+) (domain.ValidatorStatus, error) {
+    ids, err := normalizeValidatorIDs([]string{id})
+    if err != nil {
+        return domain.ValidatorStatus{}, err
+    }
+    if len(ids) == 0 {
+        return domain.ValidatorStatus{}, apierr.ErrInvalidValidatorID
+    }
+
+    switch state {
+    case "":
+        state = "head"
+    case "head", "finalized":
+    default:
+        slot, err := strconv.ParseUint(state, 10, 64)
+        if err != nil {
+            return domain.ValidatorStatus{}, apierr.ErrInvalidState
+        }
+        if err := checkSlotReached(uc.head, slot, apierr.ErrSlotInFuture); err != nil {
+            return domain.ValidatorStatus{}, err
+        }
+    }
+
+    return uc.client.GetValidatorStatus(ctx, state, ids[0])
+}
\ No newline at end of file
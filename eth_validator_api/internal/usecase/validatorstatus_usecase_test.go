@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/usecase"
+)
+
+type recordingStatusClient struct {
+    state string
+    id    string
+}
+
+func (m *recordingStatusClient) GetValidatorStatus(ctx context.Context, state, id string) (domain.ValidatorStatus, error) {
+    m.state, m.id = state, id
+    return domain.ValidatorStatus{State: state, Status: domain.ValidatorStatusActive}, nil
+}
+
+func TestValidatorStatusUseCase_States(t *testing.T) {
+    client := &recordingStatusClient{}
+    uc := usecase.NewValidatorStatusUseCase(client, staticHead{slot: 100})
+
+    for state, want := range map[string]string{"": "head", "head": "head", "finalized": "finalized", "100": "100"} {
+        if _, err := uc.Execute(context.Background(), "42", state); err != nil {
+            t.Fatalf("state %q: esperaba sin error, got %v", state, err)
+        }
+        if client.state != want || client.id != "42" {
+            t.Errorf("state %q: consulta %q/%q, esperaba %q/42", state, client.state, client.id, want)
+        }
+    }
+
+    // Pubkeys are normalized before reaching the node.
+    pubkey := "0x" + strings.Repeat("AB", 48)
+    if _, err := uc.Execute(context.Background(), pubkey, "head"); err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if client.id != strings.ToLower(pubkey) {
+        t.Errorf("id = %q, esperaba la pubkey en minúsculas", client.id)
+    }
+}
+
+func TestValidatorStatusUseCase_Errors(t *testing.T) {
+    client := &recordingStatusClient{}
+    uc := usecase.NewValidatorStatusUseCase(client, staticHead{slot: 100})
+
+    cases := []struct {
+        id, state string
+        want      error
+    }{
+        {"0x12", "head", apierr.ErrInvalidValidatorID},
+        {"", "head", apierr.ErrInvalidValidatorID},
+        {"42", "justified", apierr.ErrInvalidState},
+        {"42", "101", apierr.ErrSlotInFuture},
+    }
+    for _, c := range cases {
+        if _, err := uc.Execute(context.Background(), c.id, c.state); err != c.want {
+            t.Errorf("id %q state %q: esperaba %v, got %v", c.id, c.state, c.want, err)
+        }
+    }
+    if client.state != "" {
+        t.Errorf("no esperaba consultar el nodo, got estado %q", client.state)
+    }
+}
\ No newline at end of file
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+    "context"
+    "strconv"
+    "strings"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/port"
+)
+
+// The sync committee is split into this many subcommittees, one per subnet.
+const syncCommitteeSubnetCount = 4
+
+// ValidatorSyncDutyUseCase answers whether one validator sits in the sync
+// committee of a slot, from the committee SyncDutiesUseCase caches per period.
+type ValidatorSyncDutyUseCase struct {
+    duties     *SyncDutiesUseCase
+    validators port.ValidatorIndexResolver
+    pubkeys    port.ValidatorPubkeyResolver
+}
+
+func NewValidatorSyncDutyUseCase(
+    duties *SyncDutiesUseCase,
+    validators port.ValidatorIndexResolver,
+    pubkeys port.ValidatorPubkeyResolver,
+) *ValidatorSyncDutyUseCase {
+    return &ValidatorSyncDutyUseCase{duties: duties, validators: validators, pubkeys: pubkeys}
+}
+
+func (uc *ValidatorSyncDutyUseCase) Execute(
+    ctx context.Context,
+    slot uint64,
+    id string,
+) (domain.ValidatorSyncDuty, error) {
+    ids, err := normalizeValidatorIDs([]string{id})
+    if err != nil {
+        return domain.ValidatorSyncDuty{}, err
+    }
+    if len(ids) == 0 {
+        return domain.ValidatorSyncDuty{}, apierr.ErrInvalidValidatorID
+    }
+    if err := checkSlotReached(uc.duties.head, slot, apierr.ErrSlotTooFarInFuture); err != nil {
+        return domain.ValidatorSyncDuty{}, err
+    }
+
+    committee, err := uc.duties.committee(ctx, slot, slot/slotsPerEpoch)
+    if err != nil {
+        return domain.ValidatorSyncDuty{}, err
+    }
+    index, pubkey, err := uc.resolve(ctx, slot, ids[0])
+    if err != nil {
+        return domain.ValidatorSyncDuty{}, err
+    }
+
+    duty := domain.ValidatorSyncDuty{
+        Finality:       committee.Finality,
+        Slot:           slot,
+        Period:         syncCommitteePeriod(slot),
+        ValidatorIndex: index,
+        Pubkey:         pubkey,
+        Positions:      []int{},
+        Subcommittees:  []int{},
+    }
+    subcommitteeSize := len(committee.Validators) / syncCommitteeSubnetCount
+    for i, member := range committee.Validators {
+        if !strings.EqualFold(member, pubkey) {
+            continue
+        }
+        duty.Positions = append(duty.Positions, i)
+        // A validator can hold several positions in the same subcommittee.
+        sub := i / subcommitteeSize
+        if n := len(duty.Subcommittees); n == 0 || duty.Subcommittees[n-1] != sub {
+            duty.Subcommittees = append(duty.Subcommittees, sub)
+        }
+    }
+    duty.Member = len(duty.Positions) > 0
+    return duty, nil
+}
+
+// resolve returns both the index and the pubkey of a normalized validator id.
+func (uc *ValidatorSyncDutyUseCase) resolve(ctx context.Context, slot uint64, id string) (uint64, string, error) {
+    if strings.HasPrefix(id, "0x") {
+        indices, err := uc.validators.ValidatorIndices(ctx, slot, []string{id})
+        if err != nil {
+            return 0, "", err
+        }
+        return indices[0], id, nil
+    }
+    index, err := strconv.ParseUint(id, 10, 64)
+    if err != nil {
+        return 0, "", apierr.ErrInvalidValidatorID
+    }
+    pubkey, err := uc.pubkeys.ValidatorPubkey(ctx, index)
+    if err != nil {
+        return 0, "", err
+    }
+    return index, pubkey, nil
+}
\ No newline at end of file
@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    "eth_validator_api/internal/domain"
+    apierr "eth_validator_api/internal/errors"
+    "eth_validator_api/internal/usecase"
+)
+
+func TestValidatorSyncDutyUseCase_Positions(t *testing.T) {
+    pubkey := "0x" + strings.Repeat("ab", 47) + "05"
+    // Eight members, so each of the four subcommittees holds two positions.
+    committee := []string{"0xa", "0xPUBKEY", "0xpubkey", "0xb", strings.ToUpper(pubkey[2:]), "0xc", "0xd", pubkey}
+    client := &dummyClient{duties: domain.SyncDuties{Validators: committee}}
+    duties := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, nil, 0)
+    uc := usecase.NewValidatorSyncDutyUseCase(duties, &mockIndexResolver{}, &mockPubkeys{})
+
+    byIndex, err := uc.Execute(context.Background(), 8192, "42")
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if !byIndex.Member || byIndex.ValidatorIndex != 42 || byIndex.Period != 1 {
+        t.Fatalf("resultado inesperado: %+v", byIndex)
+    }
+    if len(byIndex.Positions) != 2 || byIndex.Positions[0] != 1 || byIndex.Positions[1] != 2 {
+        t.Errorf("posiciones = %v, esperaba [1 2]", byIndex.Positions)
+    }
+    if len(byIndex.Subcommittees) != 2 || byIndex.Subcommittees[0] != 0 || byIndex.Subcommittees[1] != 1 {
+        t.Errorf("subcomités = %v, esperaba [0 1]", byIndex.Subcommittees)
+    }
+
+    byPubkey, err := uc.Execute(context.Background(), 8193, strings.ToUpper(pubkey[:2]) + pubkey[2:])
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if byPubkey.ValidatorIndex != 5 || len(byPubkey.Positions) != 1 || byPubkey.Positions[0] != 7 || byPubkey.Subcommittees[0] != 3 {
+        t.Errorf("resultado inesperado: %+v", byPubkey)
+    }
+    if len(client.slots) != 1 {
+        t.Errorf("esperaba reutilizar el comité del periodo, got %d consultas", len(client.slots))
+    }
+}
+
+func TestValidatorSyncDutyUseCase_NotMember(t *testing.T) {
+    client := &dummyClient{duties: domain.SyncDuties{Validators: []string{"0xa", "0xb", "0xc", "0xd"}}}
+    duties := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), client, newDummyCache(), farHead, nil, 0)
+    uc := usecase.NewValidatorSyncDutyUseCase(duties, &mockIndexResolver{}, &mockPubkeys{})
+
+    res, err := uc.Execute(context.Background(), 10, "7")
+    if err != nil {
+        t.Fatalf("esperaba sin error, got %v", err)
+    }
+    if res.Member || len(res.Positions) != 0 || res.Subcommittees == nil {
+        t.Errorf("resultado inesperado: %+v", res)
+    }
+}
+
+func TestValidatorSyncDutyUseCase_InvalidID(t *testing.T) {
+    duties := usecase.NewSyncDutiesUseCase(newProposers(&mockResolver{}), &dummyClient{}, newDummyCache(), farHead, nil, 0)
+    uc := usecase.NewValidatorSyncDutyUseCase(duties, &mockIndexResolver{}, &mockPubkeys{})
+
+    for _, id := range []string{"abc", "0x12", " "} {
+        if _, err := uc.Execute(context.Background(), 10, id); err != apierr.ErrInvalidValidatorID {
+            t.Errorf("%q: esperaba ErrInvalidValidatorID, got %v", id, err)
+        }
+    }
+}
@@ -15,6 +15,26 @@ type Config struct {
         RPCWS     string
         MevRelays []string `mapstructure:"MEV_RELAYS"`
     }
+    Beacon struct {
+        HTTP    string            `mapstructure:"ETH_BEACON_HTTP"`
+        Headers map[string]string `mapstructure:"ETH_BEACON_HEADERS"`
+    }
+    Execution struct {
+        HTTP    string            `mapstructure:"ETH_EXECUTION_HTTP"`
+        Headers map[string]string `mapstructure:"ETH_EXECUTION_HEADERS"`
+        Network string            `mapstructure:"ETH_NETWORK"`
+    }
+    HeadTracker struct {
+        PollInterval time.Duration `mapstructure:"HEAD_POLL_INTERVAL"`
+    }
+    Builders struct {
+        RegistryFile string        `mapstructure:"BUILDER_REGISTRY_FILE"`
+        PollInterval time.Duration `mapstructure:"BUILDER_REGISTRY_POLL_INTERVAL"`
+    }
+    Validators struct {
+        RegistryFile string        `mapstructure:"VALIDATOR_REGISTRY_FILE"`
+        PollInterval time.Duration `mapstructure:"VALIDATOR_REGISTRY_POLL_INTERVAL"`
+    }
     Cache struct {
         SyncDuties struct {
             MaxEntries int           `mapstructure:"CACHE_SYNC_MAX_ENTRIES"`
@@ -24,6 +44,26 @@ type Config struct {
             MaxEntries int           `mapstructure:"CACHE_BLOCK_REWARD_MAX_ENTRIES"`
             TTL        time.Duration `mapstructure:"CACHE_BLOCK_REWARD_TTL"`
         }
+        BlockFees struct {
+            MaxEntries int           `mapstructure:"CACHE_BLOCK_FEES_MAX_ENTRIES"`
+            TTL        time.Duration `mapstructure:"CACHE_BLOCK_FEES_TTL"`
+        }
+        ConsensusRewards struct {
+            MaxEntries int           `mapstructure:"CACHE_CONSENSUS_REWARDS_MAX_ENTRIES"`
+            TTL        time.Duration `mapstructure:"CACHE_CONSENSUS_REWARDS_TTL"`
+        }
+        AttestationRewards struct {
+            MaxEntries int           `mapstructure:"CACHE_ATTESTATION_REWARDS_MAX_ENTRIES"`
+            TTL        time.Duration `mapstructure:"CACHE_ATTESTATION_REWARDS_TTL"`
+        }
+        SyncRewards struct {
+            MaxEntries int           `mapstructure:"CACHE_SYNC_REWARDS_MAX_ENTRIES"`
+            TTL        time.Duration `mapstructure:"CACHE_SYNC_REWARDS_TTL"`
+        }
+        Proposer struct {
+            MaxEntries int           `mapstructure:"CACHE_PROPOSER_MAX_ENTRIES"`
+            TTL        time.Duration `mapstructure:"CACHE_PROPOSER_TTL"`
+        }
     }
 	Retry struct {
         BlockReward struct {
@@ -45,13 +85,33 @@ func Load() (*Config, error) {
     v.AutomaticEnv()
 
     v.SetDefault("SERVER_ADDRESS", ":8080")
-    v.SetDefault("ETH_RPC_HTTP", "default_value")
-    v.SetDefault("ETH_RPC_WS", "default_value")
+    v.SetDefault("ETH_RPC_HTTP", "")
+    v.SetDefault("ETH_RPC_WS", "")
+    v.SetDefault("ETH_BEACON_HTTP", "")
+    v.SetDefault("ETH_BEACON_HEADERS", map[string]string{})
+    v.SetDefault("ETH_EXECUTION_HTTP", "")
+    v.SetDefault("ETH_EXECUTION_HEADERS", map[string]string{})
+    v.SetDefault("ETH_NETWORK", "mainnet")
     v.SetDefault("MEV_RELAYS", []string{})
+    v.SetDefault("HEAD_POLL_INTERVAL", "12s")
+    v.SetDefault("BUILDER_REGISTRY_FILE", "builders.json")
+    v.SetDefault("BUILDER_REGISTRY_POLL_INTERVAL", "1m")
+    v.SetDefault("VALIDATOR_REGISTRY_FILE", "data/validators.bin")
+    v.SetDefault("VALIDATOR_REGISTRY_POLL_INTERVAL", "6m24s")
     v.SetDefault("CACHE_SYNC_MAX_ENTRIES", 1024)
-    v.SetDefault("CACHE_SYNC_TTL",  "60m")
+    v.SetDefault("CACHE_SYNC_TTL",  "1m")
     v.SetDefault("CACHE_BLOCK_REWARD_MAX_ENTRIES", 1024)
-    v.SetDefault("CACHE_BLOCK_REWARD_TTL",  "60m")
+    v.SetDefault("CACHE_BLOCK_REWARD_TTL",  "1m")
+    v.SetDefault("CACHE_BLOCK_FEES_MAX_ENTRIES", 1024)
+    v.SetDefault("CACHE_BLOCK_FEES_TTL",  "1m")
+    v.SetDefault("CACHE_CONSENSUS_REWARDS_MAX_ENTRIES", 1024)
+    v.SetDefault("CACHE_CONSENSUS_REWARDS_TTL",  "1m")
+    v.SetDefault("CACHE_ATTESTATION_REWARDS_MAX_ENTRIES", 256)
+    v.SetDefault("CACHE_ATTESTATION_REWARDS_TTL",  "1m")
+    v.SetDefault("CACHE_SYNC_REWARDS_MAX_ENTRIES", 1024)
+    v.SetDefault("CACHE_SYNC_REWARDS_TTL",  "1m")
+    v.SetDefault("CACHE_PROPOSER_MAX_ENTRIES", 4096)
+    v.SetDefault("CACHE_PROPOSER_TTL",  "1m")
 	v.SetDefault("BR_TIMEOUT",   "5s")
 	v.SetDefault("BR_MAX_RETRIES", 3)
 	v.SetDefault("BR_BACKOFF",    "100ms")
@@ -71,12 +131,38 @@ func Load() (*Config, error) {
     cfg.Ethereum.RPCWS = v.GetString("ETH_RPC_WS")
     cfg.Ethereum.MevRelays = v.GetStringSlice("MEV_RELAYS")
 
+    cfg.Beacon.HTTP = firstNonEmpty(v.GetString("ETH_BEACON_HTTP"), cfg.Ethereum.RPCHTTP)
+    cfg.Beacon.Headers = v.GetStringMapString("ETH_BEACON_HEADERS")
+    cfg.Execution.HTTP = firstNonEmpty(v.GetString("ETH_EXECUTION_HTTP"), cfg.Ethereum.RPCHTTP)
+    cfg.Execution.Headers = v.GetStringMapString("ETH_EXECUTION_HEADERS")
+    cfg.Execution.Network = v.GetString("ETH_NETWORK")
+    cfg.HeadTracker.PollInterval = v.GetDuration("HEAD_POLL_INTERVAL")
+    cfg.Builders.RegistryFile = v.GetString("BUILDER_REGISTRY_FILE")
+    cfg.Builders.PollInterval = v.GetDuration("BUILDER_REGISTRY_POLL_INTERVAL")
+    cfg.Validators.RegistryFile = v.GetString("VALIDATOR_REGISTRY_FILE")
+    cfg.Validators.PollInterval = v.GetDuration("VALIDATOR_REGISTRY_POLL_INTERVAL")
+
     cfg.Cache.SyncDuties.MaxEntries = v.GetInt("CACHE_SYNC_MAX_ENTRIES")
     cfg.Cache.SyncDuties.TTL = v.GetDuration("CACHE_SYNC_TTL")
     
     cfg.Cache.BlockReward.MaxEntries = v.GetInt("CACHE_BLOCK_REWARD_MAX_ENTRIES")
     cfg.Cache.BlockReward.TTL = v.GetDuration("CACHE_BLOCK_REWARD_TTL")
 
+    cfg.Cache.BlockFees.MaxEntries = v.GetInt("CACHE_BLOCK_FEES_MAX_ENTRIES")
+    cfg.Cache.BlockFees.TTL = v.GetDuration("CACHE_BLOCK_FEES_TTL")
+
+    cfg.Cache.ConsensusRewards.MaxEntries = v.GetInt("CACHE_CONSENSUS_REWARDS_MAX_ENTRIES")
+    cfg.Cache.ConsensusRewards.TTL = v.GetDuration("CACHE_CONSENSUS_REWARDS_TTL")
+
+    cfg.Cache.AttestationRewards.MaxEntries = v.GetInt("CACHE_ATTESTATION_REWARDS_MAX_ENTRIES")
+    cfg.Cache.AttestationRewards.TTL = v.GetDuration("CACHE_ATTESTATION_REWARDS_TTL")
+
+    cfg.Cache.SyncRewards.MaxEntries = v.GetInt("CACHE_SYNC_REWARDS_MAX_ENTRIES")
+    cfg.Cache.SyncRewards.TTL = v.GetDuration("CACHE_SYNC_REWARDS_TTL")
+
+    cfg.Cache.Proposer.MaxEntries = v.GetInt("CACHE_PROPOSER_MAX_ENTRIES")
+    cfg.Cache.Proposer.TTL = v.GetDuration("CACHE_PROPOSER_TTL")
+
     cfg.Retry.BlockReward.Timeout    = v.GetDuration("BR_TIMEOUT")
     cfg.Retry.BlockReward.MaxRetries = v.GetInt("BR_MAX_RETRIES")
     cfg.Retry.BlockReward.Backoff    = v.GetDuration("BR_BACKOFF")
@@ -88,11 +174,14 @@ func Load() (*Config, error) {
     if cfg.Server.Address == "" {
         return nil, fmt.Errorf("SERVER_ADDRESS must not be empty")
     }
-    if cfg.Ethereum.RPCHTTP == "" {
-        return nil, fmt.Errorf("ETH_RPC_HTTP must not be empty")
+    if cfg.Beacon.HTTP == "" {
+        return nil, fmt.Errorf("ETH_BEACON_HTTP or ETH_RPC_HTTP must not be empty")
     }
-    if cfg.Ethereum.RPCWS == "" {
-        return nil, fmt.Errorf("ETH_RPC_WS must not be empty")
+    if cfg.Execution.HTTP == "" {
+        return nil, fmt.Errorf("ETH_EXECUTION_HTTP or ETH_RPC_HTTP must not be empty")
+    }
+    if cfg.HeadTracker.PollInterval <= 0 {
+        return nil, fmt.Errorf("HEAD_POLL_INTERVAL must be > 0")
     }
     if cfg.Retry.BlockReward.MaxRetries < 1 {
         return nil, fmt.Errorf("BR_MAX_RETRIES must be ≥ 1")
@@ -103,3 +192,12 @@ func Load() (*Config, error) {
 
     return cfg, nil
 }
+
+func firstNonEmpty(values ...string) string {
+    for _, v := range values {
+        if v != "" {
+            return v
+        }
+    }
+    return ""
+}
@@ -9,14 +9,12 @@ import (
 	"go.uber.org/zap"
 
     "eth_validator_api/internal/handler"
-    "eth_validator_api/internal/usecase"
     "eth_validator_api/pkg/config"
 )
 
 func NewRouter(
     cfg *config.Config,
-    brUC *usecase.BlockRewardUseCase,
-    sdUC *usecase.SyncDutiesUseCase,
+    uc handler.UseCases,
 ) *chi.Mux {
     r := chi.NewRouter()
 
@@ -33,7 +31,7 @@ func NewRouter(
 		}
     })
 
-    h := handler.NewHandler(brUC, sdUC)
+    h := handler.NewHandler(uc)
     h.Register(r)
 
     return r